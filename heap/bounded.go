@@ -0,0 +1,93 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+)
+
+// Bounded is a generic heap that holds at most k elements.
+// It keeps the k greatest elements (according to cmp function)
+// and drops the least one on overflow.
+// To keep the k least elements, pass a reversed cmp function.
+type Bounded[E any] struct {
+	heap Heap[E]
+	k    int
+}
+
+// NewBounded creates a new Bounded with elements of type E,
+// which holds at most k elements, with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+func NewBounded[E any](k int, cmp func(a, b E) int) *Bounded[E] {
+	return &Bounded[E]{
+		heap: Heap[E]{
+			data: make([]E, 0, max(k, 0)),
+			cmp:  cmp,
+		},
+		k: k,
+	}
+}
+
+// Len returns the number of elements in the heap.
+func (b *Bounded[E]) Len() int {
+	return b.heap.Len()
+}
+
+// Cap returns the maximum number of elements the heap can hold.
+func (b *Bounded[E]) Cap() int {
+	return b.k
+}
+
+// Push pushes the element x onto the heap.
+// If the heap is full, the least element among x and the elements of the heap
+// is dropped and returned along with true.
+func (b *Bounded[E]) Push(x E) (dropped E, ok bool) {
+	if b.heap.Len() < b.k {
+		b.heap.Push(x)
+		return dropped, false
+	}
+	if b.k <= 0 {
+		return x, true
+	}
+	return b.heap.PushPop(x), true
+}
+
+// Peek returns the least element of the heap, which is the next one to be dropped.
+func (b *Bounded[E]) Peek() E {
+	return b.heap.At(0)
+}
+
+// Pop removes and returns the least element (according to cmp function) from the heap.
+func (b *Bounded[E]) Pop() E {
+	return b.heap.Pop()
+}
+
+// Sorted returns the elements of the heap
+// sorted from the greatest to the least (according to cmp function).
+// The heap is left unchanged.
+func (b *Bounded[E]) Sorted() []E {
+	res := slices.Clone(b.heap.data)
+	slices.SortFunc(res, func(x, y E) int {
+		return b.heap.cmp(y, x)
+	})
+	return res
+}
+
+// TopK returns the k greatest elements (according to cmp function) of seq
+// sorted from the greatest to the least.
+// Runs in O(n log k) time, where n is the number of elements in seq.
+func TopK[E any](seq iter.Seq[E], k int, cmp func(a, b E) int) []E {
+	b := NewBounded(k, cmp)
+	for x := range seq {
+		b.Push(x)
+	}
+	return b.Sorted()
+}
+
+// BottomK returns the k least elements (according to cmp function) of seq
+// sorted from the least to the greatest.
+// Runs in O(n log k) time, where n is the number of elements in seq.
+func BottomK[E any](seq iter.Seq[E], k int, cmp func(a, b E) int) []E {
+	return TopK(seq, k, func(a, b E) int {
+		return cmp(b, a)
+	})
+}
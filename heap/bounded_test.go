@@ -0,0 +1,55 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/infastin/gorack/heap"
+)
+
+func TestBounded_Push(t *testing.T) {
+	b := heap.NewBounded(3, cmpInt)
+
+	for _, x := range []int{5, 1, 4} {
+		if _, ok := b.Push(x); ok {
+			t.Errorf("Push(%d): expected nothing to be dropped", x)
+		}
+	}
+	if dropped, ok := b.Push(0); !ok || dropped != 0 {
+		t.Errorf("Push(0): expected=(0, true) got=(%d, %t)", dropped, ok)
+	}
+	if dropped, ok := b.Push(7); !ok || dropped != 1 {
+		t.Errorf("Push(7): expected=(1, true) got=(%d, %t)", dropped, ok)
+	}
+
+	if got, expected := b.Sorted(), []int{7, 5, 4}; !slices.Equal(expected, got) {
+		t.Errorf("slices must be equal: expected=%v got=%v", expected, got)
+	}
+	if n := b.Len(); n != 3 {
+		t.Errorf("Len(): expected=3 got=%d", n)
+	}
+	if x := b.Pop(); x != 4 {
+		t.Errorf("Pop(): expected=4 got=%d", x)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	data := rand.Perm(1000)
+	sorted := slices.Sorted(slices.Values(data))
+
+	for _, k := range []int{0, 1, 10, 1000, 2000} {
+		n := min(k, len(data))
+
+		expected := slices.Clone(sorted[len(sorted)-n:])
+		slices.Reverse(expected)
+		if got := heap.TopK(slices.Values(data), k, cmpInt); !slices.Equal(expected, got) {
+			t.Errorf("TopK(%d): slices must be equal: expected=%v got=%v", k, expected, got)
+		}
+
+		expected = sorted[:n]
+		if got := heap.BottomK(slices.Values(data), k, cmpInt); !slices.Equal(expected, got) {
+			t.Errorf("BottomK(%d): slices must be equal: expected=%v got=%v", k, expected, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package heap
+
+// DAry is a generic d-ary min-heap backed by a slice.
+//
+// Compared to Heap, which is a binary heap, DAry has a smaller height,
+// which makes Push and decreasing the value of an element followed by Fix cheaper,
+// at the cost of more comparisons done by Pop and Remove.
+type DAry[E any] struct {
+	data []E
+	cmp  func(a, b E) int
+	d    int
+}
+
+// NewDAry creates a new DAry with elements of type E,
+// where each node has at most d children, with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+//
+// Panics if d is less than 2.
+func NewDAry[E any](d int, cmp func(a, b E) int) *DAry[E] {
+	if d < 2 {
+		panic("heap: d-ary heap must have at least 2 children per node")
+	}
+	return &DAry[E]{
+		data: make([]E, 0),
+		cmp:  cmp,
+		d:    d,
+	}
+}
+
+// At returns the element at the index i from the heap.
+func (h *DAry[E]) At(i int) E {
+	return h.data[i]
+}
+
+// Set changes the element at the index i in the heap.
+// Fix should be called afterwards if this change breaks heap ordering.
+func (h *DAry[E]) Set(i int, x E) {
+	h.data[i] = x
+}
+
+// Len returns the number of elements in the heap.
+func (h *DAry[E]) Len() int {
+	return len(h.data)
+}
+
+// Peek returns the minimum element (according to cmp function)
+// without removing it from the heap.
+func (h *DAry[E]) Peek() E {
+	return h.data[0]
+}
+
+// Push pushes the element x onto the heap.
+func (h *DAry[E]) Push(x E) {
+	h.data = append(h.data, x)
+	h.up(len(h.data) - 1)
+}
+
+// Pop removes and returns the minimum element (according to cmp function) from the heap.
+// Pop is equivalent to Remove(0).
+func (h *DAry[E]) Pop() E {
+	n := len(h.data) - 1
+	if n != 0 {
+		h.swap(0, n)
+		h.down(0, n)
+	}
+	return h.pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+func (h *DAry[E]) Remove(i int) E {
+	n := len(h.data) - 1
+	if i != n {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(i) followed by a Push of the new value.
+func (h *DAry[E]) Fix(i int) {
+	if !h.down(i, len(h.data)) {
+		h.up(i)
+	}
+}
+
+func (h *DAry[E]) pop() E {
+	var res E
+	n := len(h.data) - 1
+	res, h.data[n] = h.data[n], res
+	h.data = h.data[:n]
+	return res
+}
+
+func (h *DAry[E]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+// up fixes heap ordering starting from index i and ending at index 0.
+func (h *DAry[E]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / h.d // parent
+		if h.cmp(h.data[i], h.data[p]) >= 0 {
+			break
+		}
+		h.swap(i, p)
+		i = p
+	}
+}
+
+// down fixes heap ordering starting from index i0 and ending at index n.
+// Returns false if the element at index i0 is not greater that its children
+// or has no children at all.
+func (h *DAry[E]) down(i0, n int) bool {
+	i := i0
+	for {
+		first := h.d*i + 1           // first child
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		c := first
+		for c2 := first + 1; c2 < min(first+h.d, n); c2++ {
+			if h.cmp(h.data[c2], h.data[c]) < 0 {
+				c = c2
+			}
+		}
+		if h.cmp(h.data[c], h.data[i]) >= 0 {
+			break
+		}
+		h.swap(i, c)
+		i = c
+	}
+	return i > i0
+}
@@ -0,0 +1,90 @@
+package heap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/infastin/gorack/heap"
+)
+
+func verifyDAry(t *testing.T, h *heap.DAry[int], d int) {
+	t.Helper()
+	for i := 1; i < h.Len(); i++ {
+		if p := (i - 1) / d; h.At(i) < h.At(p) {
+			t.Errorf("heap invariant invalidated [%d] = %d > [%d] = %d", p, h.At(p), i, h.At(i))
+			return
+		}
+	}
+}
+
+func TestDAry(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			h := heap.NewDAry(d, cmpInt)
+			for range 200 {
+				h.Push(rand.Intn(1000))
+				verifyDAry(t, h, d)
+			}
+
+			for i := 100; i > 0; i-- {
+				elem := rand.Intn(h.Len())
+				switch i % 3 {
+				case 0:
+					h.Set(elem, h.At(elem)/2)
+					h.Fix(elem)
+				case 1:
+					h.Set(elem, h.At(elem)*2)
+					h.Fix(elem)
+				default:
+					h.Remove(elem)
+				}
+				verifyDAry(t, h, d)
+			}
+
+			got := make([]int, 0, h.Len())
+			for h.Len() > 0 {
+				got = append(got, h.Pop())
+				verifyDAry(t, h, d)
+			}
+			if !slices.IsSorted(got) {
+				t.Errorf("Pop(): expected elements to be popped in order, got %v", got)
+			}
+		})
+	}
+}
+
+// benchmarkDecreaseKey simulates a graph search workload,
+// where the values of elements are repeatedly decreased
+// and the minimum element is occasionally popped.
+func benchmarkDecreaseKey(b *testing.B, h heap.Interface[int]) {
+	const n = 1 << 14
+
+	rng := rand.New(rand.NewSource(1))
+	for range n {
+		h.Push(rng.Intn(1 << 30))
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		if i%8 == 0 {
+			h.Push(h.Pop() + rng.Intn(1<<20))
+			continue
+		}
+		elem := rng.Intn(h.Len())
+		h.Set(elem, h.At(elem)-rng.Intn(1<<20))
+		h.Fix(elem)
+	}
+}
+
+func BenchmarkDecreaseKey(b *testing.B) {
+	b.Run("Heap", func(b *testing.B) {
+		benchmarkDecreaseKey(b, heap.New(cmpInt))
+	})
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("DAry/d=%d", d), func(b *testing.B) {
+			benchmarkDecreaseKey(b, heap.NewDAry(d, cmpInt))
+		})
+	}
+}
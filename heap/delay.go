@@ -0,0 +1,195 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to DelayQueue.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer created by Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type delayQueueConfig struct {
+	clock Clock
+}
+
+// DelayQueueOption configures DelayQueue.
+type DelayQueueOption func(cfg *delayQueueConfig)
+
+// WithClock sets the clock used by DelayQueue.
+// By default, the system clock is used.
+func WithClock(clock Clock) DelayQueueOption {
+	return func(cfg *delayQueueConfig) {
+		cfg.clock = clock
+	}
+}
+
+type delayed[E any] struct {
+	value E
+	at    time.Time
+	index int
+}
+
+// DelayQueue is a thread-safe queue of elements,
+// each of which can only be popped once its ready time has come.
+// Elements are popped in the order of their ready time.
+type DelayQueue[E any] struct {
+	mu    sync.Mutex
+	heap  Heap[*delayed[E]]
+	clock Clock
+	// changed is closed and replaced every time
+	// the element with the earliest ready time changes.
+	changed chan struct{}
+}
+
+// NewDelayQueue creates a new DelayQueue with elements of type E.
+func NewDelayQueue[E any](opts ...DelayQueueOption) *DelayQueue[E] {
+	cfg := delayQueueConfig{
+		clock: systemClock{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &DelayQueue[E]{
+		mu: sync.Mutex{},
+		heap: Heap[*delayed[E]]{
+			data: make([]*delayed[E], 0),
+			cmp: func(a, b *delayed[E]) int {
+				return a.at.Compare(b.at)
+			},
+			setIndex: func(d *delayed[E], i int) {
+				d.index = i
+			},
+		},
+		clock:   cfg.clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Len returns the number of elements in the queue,
+// including the ones that are not ready yet.
+func (q *DelayQueue[E]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Len()
+}
+
+// Push pushes the element x onto the queue,
+// which becomes ready to be popped at the time at.
+//
+// Returns cancel function, which removes the element from the queue.
+// Calling cancel reports whether the element has been removed,
+// i.e. it returns false if the element has already been popped or canceled.
+func (q *DelayQueue[E]) Push(x E, at time.Time) (cancel func() bool) {
+	d := &delayed[E]{value: x, at: at, index: -1}
+
+	q.mu.Lock()
+	q.heap.Push(d)
+	if d.index == 0 {
+		q.notify()
+	}
+	q.mu.Unlock()
+
+	return func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if d.index < 0 {
+			return false
+		}
+		first := d.index == 0
+		q.heap.Remove(d.index)
+		if first {
+			q.notify()
+		}
+		return true
+	}
+}
+
+// Pop removes and returns the element with the earliest ready time from the queue.
+// Waits until the element is ready, or until an element is pushed if the queue is empty.
+//
+// Returns the context error if the context is done before an element is popped.
+func (q *DelayQueue[E]) Pop(ctx context.Context) (E, error) {
+	for {
+		q.mu.Lock()
+		if q.heap.Len() == 0 {
+			changed := q.changed
+			q.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				var zero E
+				return zero, ctx.Err()
+			case <-changed:
+			}
+
+			continue
+		}
+
+		delay := q.heap.At(0).at.Sub(q.clock.Now())
+		if delay <= 0 {
+			x := q.heap.Pop().value
+			q.mu.Unlock()
+			return x, nil
+		}
+
+		changed := q.changed
+		q.mu.Unlock()
+
+		timer := q.clock.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			var zero E
+			return zero, ctx.Err()
+		case <-changed:
+			timer.Stop()
+		case <-timer.C():
+		}
+	}
+}
+
+// TryPop removes and returns the element with the earliest ready time
+// from the queue without waiting.
+// Returns false if the queue is empty or the element is not ready yet.
+func (q *DelayQueue[E]) TryPop() (x E, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.heap.Len() == 0 || q.heap.At(0).at.After(q.clock.Now()) {
+		return x, false
+	}
+	return q.heap.Pop().value, true
+}
+
+func (q *DelayQueue[E]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
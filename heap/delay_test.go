@@ -0,0 +1,147 @@
+package heap_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/heap"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	added  chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		added: make(chan struct{}, 16),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) heap.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	c.added <- struct{}{}
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped.Load() {
+			continue
+		}
+		if !t.at.After(c.now) {
+			t.c <- c.now
+			continue
+		}
+		timers = append(timers, t)
+	}
+	c.timers = timers
+}
+
+type fakeTimer struct {
+	at      time.Time
+	c       chan time.Time
+	stopped atomic.Bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	return !t.stopped.Swap(true)
+}
+
+func TestDelayQueue_Pop(t *testing.T) {
+	clock := newFakeClock()
+	q := heap.NewDelayQueue[string](heap.WithClock(clock))
+
+	q.Push("b", clock.Now().Add(2*time.Second))
+
+	result := make(chan string, 1)
+	go func() {
+		x, err := q.Pop(context.Background())
+		if err != nil {
+			t.Errorf("Pop(): unexpected error: %s", err.Error())
+		}
+		result <- x
+	}()
+
+	<-clock.added
+	if _, ok := q.TryPop(); ok {
+		t.Error("TryPop(): expected element to not be ready")
+	}
+
+	// Pushing an earlier element must re-arm the timer.
+	q.Push("a", clock.Now().Add(time.Second))
+	<-clock.added
+
+	clock.Advance(time.Second)
+	if x := <-result; x != "a" {
+		t.Errorf("Pop(): expected=a got=%s", x)
+	}
+
+	clock.Advance(time.Second)
+	if x, ok := q.TryPop(); !ok || x != "b" {
+		t.Errorf("TryPop(): expected=(b, true) got=(%s, %t)", x, ok)
+	}
+}
+
+func TestDelayQueue_cancel(t *testing.T) {
+	clock := newFakeClock()
+	q := heap.NewDelayQueue[string](heap.WithClock(clock))
+
+	cancelA := q.Push("a", clock.Now().Add(time.Second))
+	q.Push("b", clock.Now().Add(2*time.Second))
+
+	if !cancelA() {
+		t.Error("cancel(): expected element to be removed")
+	}
+	if cancelA() {
+		t.Error("cancel(): expected element to be already removed")
+	}
+
+	clock.Advance(time.Second)
+	if _, ok := q.TryPop(); ok {
+		t.Error("TryPop(): expected canceled element to not be popped")
+	}
+
+	clock.Advance(time.Second)
+	if x, ok := q.TryPop(); !ok || x != "b" {
+		t.Errorf("TryPop(): expected=(b, true) got=(%s, %t)", x, ok)
+	}
+	if n := q.Len(); n != 0 {
+		t.Errorf("Len(): expected=0 got=%d", n)
+	}
+}
+
+func TestDelayQueue_Pop_context(t *testing.T) {
+	q := heap.NewDelayQueue[int]()
+	q.Push(1, time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Pop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Pop(): expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package heap
+
+import "errors"
+
+var (
+	ErrClosed = errors.New("heap: queue is closed")
+	ErrFull   = errors.New("heap: queue is full")
+)
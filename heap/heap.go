@@ -1,9 +1,36 @@
 package heap
 
+import (
+	"iter"
+	"slices"
+)
+
+// Interface is implemented by the index-addressable heaps of this package,
+// so they can be used interchangeably.
+type Interface[E any] interface {
+	Len() int
+	At(i int) E
+	Set(i int, x E)
+	Peek() E
+	Push(x E)
+	Pop() E
+	Remove(i int) E
+	Fix(i int)
+}
+
+var (
+	_ Interface[int] = (*Heap[int])(nil)
+	_ Interface[int] = (*DAry[int])(nil)
+	_ Interface[int] = (*Stable[int])(nil)
+)
+
 // Heap is a generic min-heap backed by a slice.
 type Heap[E any] struct {
 	data []E
 	cmp  func(a, b E) int
+	// setIndex, if not nil, is called whenever an element changes its index.
+	// The index is -1 when the element is removed from the heap.
+	setIndex func(x E, i int)
 }
 
 // New creates a new Heap with elements of type E
@@ -18,6 +45,23 @@ func New[E any](cmp func(a, b E) int) *Heap[E] {
 	}
 }
 
+// NewFrom creates a new Heap from the elements of the slice s
+// with the comparison function cmp in O(n) time.
+// See New for the requirements imposed on cmp.
+//
+// The heap takes ownership of s, so the caller should not use s afterwards.
+func NewFrom[E any](s []E, cmp func(a, b E) int) *Heap[E] {
+	h := &Heap[E]{
+		data: s,
+		cmp:  cmp,
+	}
+	n := len(h.data)
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+	return h
+}
+
 // At returns the element at the index i from the heap.
 func (h *Heap[E]) At(i int) E {
 	return h.data[i]
@@ -27,6 +71,9 @@ func (h *Heap[E]) At(i int) E {
 // Fix should be called afterwards if this change breaks heap ordering.
 func (h *Heap[E]) Set(i int, x E) {
 	h.data[i] = x
+	if h.setIndex != nil {
+		h.setIndex(x, i)
+	}
 }
 
 // Len returns the number of elements in the heap.
@@ -37,9 +84,42 @@ func (h *Heap[E]) Len() int {
 // Push pushes the element x onto the heap.
 func (h *Heap[E]) Push(x E) {
 	h.data = append(h.data, x)
+	if h.setIndex != nil {
+		h.setIndex(x, len(h.data)-1)
+	}
 	h.up(len(h.data) - 1)
 }
 
+// Peek returns the minimum element (according to cmp function)
+// without removing it from the heap.
+func (h *Heap[E]) Peek() E {
+	return h.data[0]
+}
+
+// PushPop pushes the element x onto the heap,
+// then removes and returns the minimum element (according to cmp function) from the heap.
+// PushPop is more efficient than Push followed by a separate call to Pop.
+func (h *Heap[E]) PushPop(x E) E {
+	if len(h.data) == 0 || h.cmp(x, h.data[0]) <= 0 {
+		return x
+	}
+	return h.Replace(x)
+}
+
+// Replace removes and returns the minimum element (according to cmp function) from the heap,
+// then pushes the element x onto the heap.
+// The heap must not be empty.
+// Replace is more efficient than Pop followed by a separate call to Push.
+func (h *Heap[E]) Replace(x E) E {
+	res := h.data[0]
+	if h.setIndex != nil {
+		h.setIndex(res, -1)
+	}
+	h.Set(0, x)
+	h.down(0, len(h.data))
+	return res
+}
+
 // Pop removes and returns the minimum element (according to cmp function) from the heap.
 // Pop is equivalent to Remove(0).
 func (h *Heap[E]) Pop() E {
@@ -72,16 +152,68 @@ func (h *Heap[E]) Fix(i int) {
 	}
 }
 
+// Clear removes all elements from the heap.
+func (h *Heap[E]) Clear() {
+	if h.setIndex != nil {
+		for _, x := range h.data {
+			h.setIndex(x, -1)
+		}
+	}
+	clear(h.data)
+	h.data = h.data[:0]
+}
+
+// Clone returns a copy of the heap.
+// The elements are copied using assignment, so this is a shallow clone.
+func (h *Heap[E]) Clone() *Heap[E] {
+	return &Heap[E]{
+		data: slices.Clone(h.data),
+		cmp:  h.cmp,
+	}
+}
+
+// All returns an iterator over the elements of the heap in no particular order.
+// The heap must not be modified during iteration.
+func (h *Heap[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, x := range h.data {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes elements from the heap
+// and yields them in ascending order (according to cmp function).
+// Elements that are not yielded, because iteration stopped early, remain in the heap.
+func (h *Heap[E]) Drain() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for len(h.data) != 0 {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
+
 func (h *Heap[E]) pop() E {
 	var res E
 	n := len(h.data) - 1
 	res, h.data[n] = h.data[n], res
 	h.data = h.data[:n]
+	if h.setIndex != nil {
+		h.setIndex(res, -1)
+	}
 	return res
 }
 
 func (h *Heap[E]) swap(i, j int) {
 	h.data[i], h.data[j] = h.data[j], h.data[i]
+	if h.setIndex != nil {
+		h.setIndex(h.data[i], i)
+		h.setIndex(h.data[j], j)
+	}
 }
 
 // up fixes heap ordering starting from index i and ending at index 0.
@@ -2,6 +2,7 @@ package heap_test
 
 import (
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/infastin/gorack/heap"
@@ -143,3 +144,86 @@ func TestFix(t *testing.T) {
 		verify(t, h, 0)
 	}
 }
+
+func TestNewFrom(t *testing.T) {
+	data := rand.Perm(100)
+	h := heap.NewFrom(data, cmpInt)
+	verify(t, h, 0)
+
+	if n := h.Len(); n != 100 {
+		t.Fatalf("Len(): expected=100 got=%d", n)
+	}
+
+	for i := 0; h.Len() > 0; i++ {
+		if x := h.Pop(); x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+		verify(t, h, 0)
+	}
+}
+
+func TestPushPop_Replace(t *testing.T) {
+	h := heap.New(cmpInt)
+
+	if x := h.PushPop(5); x != 5 {
+		t.Errorf("PushPop(5) on empty heap got %d; want 5", x)
+	}
+
+	for i := 10; i < 20; i++ {
+		h.Push(i)
+	}
+
+	if x := h.PushPop(5); x != 5 {
+		t.Errorf("PushPop(5) got %d; want 5", x)
+	}
+	if x := h.PushPop(15); x != 10 {
+		t.Errorf("PushPop(15) got %d; want 10", x)
+	}
+	verify(t, h, 0)
+
+	if x := h.Replace(0); x != 11 {
+		t.Errorf("Replace(0) got %d; want 11", x)
+	}
+	if x := h.Peek(); x != 0 {
+		t.Errorf("Peek() got %d; want 0", x)
+	}
+	verify(t, h, 0)
+}
+
+func TestClone_Clear(t *testing.T) {
+	h := heap.NewFrom(rand.Perm(10), cmpInt)
+	c := h.Clone()
+
+	h.Clear()
+	if n := h.Len(); n != 0 {
+		t.Errorf("Len() after Clear() got %d; want 0", n)
+	}
+
+	if got := slices.Sorted(c.All()); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("All() of the clone got %v", got)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	h := heap.NewFrom(rand.Perm(10), cmpInt)
+
+	got := make([]int, 0, 5)
+	for x := range h.Drain() {
+		got = append(got, x)
+		if len(got) == 5 {
+			break
+		}
+	}
+
+	if !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Drain() got %v; want [0 1 2 3 4]", got)
+	}
+	if n := h.Len(); n != 5 {
+		t.Errorf("Len() after Drain() got %d; want 5", n)
+	}
+
+	got = slices.Collect(h.Drain())
+	if !slices.Equal(got, []int{5, 6, 7, 8, 9}) {
+		t.Errorf("Drain() got %v; want [5 6 7 8 9]", got)
+	}
+}
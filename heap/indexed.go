@@ -0,0 +1,89 @@
+package heap
+
+// Handle is a stable reference to an element of Indexed.
+// It remains valid while the element stays in the heap,
+// regardless of how other pushes and pops move the element around.
+type Handle[E any] struct {
+	value E
+	index int
+}
+
+// Value returns the value of the element referenced by the handle.
+func (h *Handle[E]) Value() E {
+	return h.value
+}
+
+// Indexed is a generic addressable min-heap.
+// Every pushed element is given a Handle, which can be used
+// to update or remove the element in O(log n).
+type Indexed[E any] struct {
+	heap Heap[*Handle[E]]
+}
+
+// NewIndexed creates a new Indexed with elements of type E
+// with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+func NewIndexed[E any](cmp func(a, b E) int) *Indexed[E] {
+	return &Indexed[E]{
+		heap: Heap[*Handle[E]]{
+			data: make([]*Handle[E], 0),
+			cmp: func(a, b *Handle[E]) int {
+				return cmp(a.value, b.value)
+			},
+			setIndex: func(h *Handle[E], i int) {
+				h.index = i
+			},
+		},
+	}
+}
+
+// Len returns the number of elements in the heap.
+func (h *Indexed[E]) Len() int {
+	return h.heap.Len()
+}
+
+// Push pushes the element x onto the heap
+// and returns the handle referencing it.
+func (h *Indexed[E]) Push(x E) *Handle[E] {
+	handle := &Handle[E]{value: x, index: -1}
+	h.heap.Push(handle)
+	return handle
+}
+
+// Peek returns the handle of the minimum element (according to cmp function)
+// without removing it from the heap.
+func (h *Indexed[E]) Peek() *Handle[E] {
+	return h.heap.At(0)
+}
+
+// Pop removes and returns the minimum element (according to cmp function) from the heap.
+func (h *Indexed[E]) Pop() E {
+	return h.heap.Pop().value
+}
+
+// Contains reports whether the element referenced by the handle is in the heap.
+func (h *Indexed[E]) Contains(handle *Handle[E]) bool {
+	return handle.index >= 0 && handle.index < h.heap.Len() && h.heap.At(handle.index) == handle
+}
+
+// Update changes the value of the element referenced by the handle
+// and re-establishes the heap ordering.
+// Returns false if the element is not in the heap.
+func (h *Indexed[E]) Update(handle *Handle[E], x E) bool {
+	if !h.Contains(handle) {
+		return false
+	}
+	handle.value = x
+	h.heap.Fix(handle.index)
+	return true
+}
+
+// Remove removes the element referenced by the handle from the heap.
+// Returns false if the element is not in the heap.
+func (h *Indexed[E]) Remove(handle *Handle[E]) bool {
+	if !h.Contains(handle) {
+		return false
+	}
+	h.heap.Remove(handle.index)
+	return true
+}
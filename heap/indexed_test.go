@@ -0,0 +1,79 @@
+package heap_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/infastin/gorack/heap"
+)
+
+func TestIndexed(t *testing.T) {
+	h := heap.NewIndexed(cmpInt)
+
+	handles := make([]*heap.Handle[int], 0, 100)
+	for range 100 {
+		handles = append(handles, h.Push(rand.Intn(1000)))
+	}
+
+	for i := range 50 {
+		handle := handles[rand.Intn(len(handles))]
+		if i&1 == 0 {
+			if !h.Update(handle, rand.Intn(1000)) {
+				t.Errorf("Update(): expected handle to be in the heap")
+			}
+		} else {
+			if !h.Remove(handle) {
+				t.Errorf("Remove(): expected handle to be in the heap")
+			}
+			if h.Contains(handle) {
+				t.Errorf("Contains(): expected handle to not be in the heap")
+			}
+			if h.Update(handle, 0) {
+				t.Errorf("Update(): expected removed handle to be rejected")
+			}
+			handles = slices.DeleteFunc(handles, func(x *heap.Handle[int]) bool {
+				return x == handle
+			})
+		}
+	}
+
+	expected := make([]int, 0, len(handles))
+	for _, handle := range handles {
+		if !h.Contains(handle) {
+			t.Errorf("Contains(): expected handle to be in the heap")
+		}
+		expected = append(expected, handle.Value())
+	}
+	slices.Sort(expected)
+
+	got := make([]int, 0, h.Len())
+	for h.Len() > 0 {
+		got = append(got, h.Pop())
+	}
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("slices must be equal: expected=%v got=%v", expected, got)
+	}
+
+	for _, handle := range handles {
+		if h.Contains(handle) {
+			t.Errorf("Contains(): expected popped handle to not be in the heap")
+		}
+	}
+}
+
+func TestIndexed_foreignHandle(t *testing.T) {
+	h1 := heap.NewIndexed(cmpInt)
+	h2 := heap.NewIndexed(cmpInt)
+
+	handle := h1.Push(1)
+	h2.Push(2)
+
+	if h2.Contains(handle) {
+		t.Error("Contains(): expected handle of another heap to not be in the heap")
+	}
+	if h2.Remove(handle) {
+		t.Error("Remove(): expected handle of another heap to be rejected")
+	}
+}
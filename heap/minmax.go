@@ -0,0 +1,185 @@
+package heap
+
+import "math/bits"
+
+// MinMax is a generic min-max heap (double-ended priority queue) backed by a slice.
+// Both the minimum and the maximum elements can be accessed in O(1)
+// and removed in O(log n).
+type MinMax[E any] struct {
+	data []E
+	cmp  func(a, b E) int
+}
+
+// NewMinMax creates a new MinMax with elements of type E
+// with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+func NewMinMax[E any](cmp func(a, b E) int) *MinMax[E] {
+	return &MinMax[E]{
+		data: make([]E, 0),
+		cmp:  cmp,
+	}
+}
+
+// At returns the element at the index i from the heap.
+func (h *MinMax[E]) At(i int) E {
+	return h.data[i]
+}
+
+// Set changes the element at the index i in the heap.
+// Fix should be called afterwards if this change breaks heap ordering.
+func (h *MinMax[E]) Set(i int, x E) {
+	h.data[i] = x
+}
+
+// Len returns the number of elements in the heap.
+func (h *MinMax[E]) Len() int {
+	return len(h.data)
+}
+
+// Push pushes the element x onto the heap.
+func (h *MinMax[E]) Push(x E) {
+	h.data = append(h.data, x)
+	h.up(len(h.data) - 1)
+}
+
+// PeekMin returns the minimum element (according to cmp function)
+// without removing it from the heap.
+func (h *MinMax[E]) PeekMin() E {
+	return h.data[0]
+}
+
+// PeekMax returns the maximum element (according to cmp function)
+// without removing it from the heap.
+func (h *MinMax[E]) PeekMax() E {
+	return h.data[h.maxIndex()]
+}
+
+// PopMin removes and returns the minimum element (according to cmp function) from the heap.
+// PopMin is equivalent to Remove(0).
+func (h *MinMax[E]) PopMin() E {
+	return h.Remove(0)
+}
+
+// PopMax removes and returns the maximum element (according to cmp function) from the heap.
+func (h *MinMax[E]) PopMax() E {
+	return h.Remove(h.maxIndex())
+}
+
+// Remove removes and returns the element at index i from the heap.
+func (h *MinMax[E]) Remove(i int) E {
+	n := len(h.data) - 1
+	if i != n {
+		h.swap(i, n)
+	}
+	var res E
+	res, h.data[n] = h.data[n], res
+	h.data = h.data[:n]
+	if i != n {
+		h.Fix(i)
+	}
+	return res
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(i) followed by a Push of the new value.
+func (h *MinMax[E]) Fix(i int) {
+	if !h.up(i) {
+		h.down(i)
+	}
+}
+
+func (h *MinMax[E]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.cmp(h.data[2], h.data[1]) > 0 {
+		return 2
+	}
+	return 1
+}
+
+func (h *MinMax[E]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+// less reports whether the element at index i should be closer to the root
+// than the element at index j on a level of the given kind.
+func (h *MinMax[E]) less(i, j int, minLevel bool) bool {
+	if minLevel {
+		return h.cmp(h.data[i], h.data[j]) < 0
+	}
+	return h.cmp(h.data[i], h.data[j]) > 0
+}
+
+// isMinLevel reports whether index i is on a min level.
+// Levels are numbered from 0 with the root being on a min level,
+// and min and max levels alternate.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// up fixes heap ordering starting from index i and ending at index 0.
+// Returns false if the element at index i is not moved.
+func (h *MinMax[E]) up(i int) bool {
+	if i == 0 {
+		return false
+	}
+	minLevel := isMinLevel(i)
+	moved := false
+	if p := (i - 1) / 2; h.less(p, i, minLevel) {
+		// The element belongs to the levels of the opposite kind,
+		// while the element moved from the parent may have to go down.
+		h.swap(i, p)
+		h.down(i)
+		i, minLevel, moved = p, !minLevel, true
+	}
+	for i > 2 {
+		g := (i - 3) / 4 // grandparent
+		if !h.less(i, g, minLevel) {
+			break
+		}
+		h.swap(i, g)
+		i, moved = g, true
+	}
+	return moved
+}
+
+// down fixes heap ordering starting from index i and ending at the bottom of the heap.
+func (h *MinMax[E]) down(i int) {
+	minLevel := isMinLevel(i)
+	n := len(h.data)
+	for {
+		c := 2*i + 1         // left child
+		if c >= n || c < 0 { // c < 0 after int overflow
+			break
+		}
+
+		// Find the extreme element among children and grandchildren.
+		m := c
+		if c+1 < n && h.less(c+1, m, minLevel) {
+			m = c + 1
+		}
+		grandchild := false
+		for g := 2*c + 1; g < min(2*c+5, n); g++ {
+			if h.less(g, m, minLevel) {
+				m, grandchild = g, true
+			}
+		}
+
+		if !h.less(m, i, minLevel) {
+			break
+		}
+		h.swap(m, i)
+		if !grandchild {
+			break
+		}
+		if p := (m - 1) / 2; h.less(p, m, minLevel) {
+			h.swap(m, p)
+		}
+		i = m
+	}
+}
@@ -0,0 +1,98 @@
+package heap_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/infastin/gorack/heap"
+)
+
+func verifyMinMax(t *testing.T, h *heap.MinMax[int]) {
+	t.Helper()
+	n := h.Len()
+	for i := 1; i < n; i++ {
+		for p := (i - 1) / 2; ; p = (p - 1) / 2 {
+			pMinLevel := bits.Len(uint(p+1))%2 == 1
+			if pMinLevel && h.At(p) > h.At(i) {
+				t.Errorf("heap invariant invalidated [%d] = %d > [%d] = %d", p, h.At(p), i, h.At(i))
+				return
+			}
+			if !pMinLevel && h.At(p) < h.At(i) {
+				t.Errorf("heap invariant invalidated [%d] = %d < [%d] = %d", p, h.At(p), i, h.At(i))
+				return
+			}
+			if p == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	h := heap.NewMinMax(cmpInt)
+	verifyMinMax(t, h)
+
+	data := rand.Perm(100)
+	for _, x := range data {
+		h.Push(x)
+		verifyMinMax(t, h)
+	}
+
+	lo, hi := 0, 99
+	for h.Len() > 0 {
+		if x := h.PeekMin(); x != lo {
+			t.Errorf("PeekMin(): expected=%d got=%d", lo, x)
+		}
+		if x := h.PeekMax(); x != hi {
+			t.Errorf("PeekMax(): expected=%d got=%d", hi, x)
+		}
+		if h.Len()%2 == 0 {
+			if x := h.PopMin(); x != lo {
+				t.Errorf("PopMin(): expected=%d got=%d", lo, x)
+			}
+			lo++
+		} else {
+			if x := h.PopMax(); x != hi {
+				t.Errorf("PopMax(): expected=%d got=%d", hi, x)
+			}
+			hi--
+		}
+		verifyMinMax(t, h)
+	}
+}
+
+func TestMinMax_Fix_Remove(t *testing.T) {
+	h := heap.NewMinMax(cmpInt)
+	for range 200 {
+		h.Push(rand.Intn(1000))
+	}
+	verifyMinMax(t, h)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.Len())
+		if i&1 == 0 {
+			h.Set(elem, rand.Intn(1000))
+			h.Fix(elem)
+		} else {
+			h.Remove(elem)
+		}
+		verifyMinMax(t, h)
+	}
+
+	expected := make([]int, 0, h.Len())
+	for i := range h.Len() {
+		expected = append(expected, h.At(i))
+	}
+	slices.Sort(expected)
+
+	got := make([]int, 0, h.Len())
+	for h.Len() > 0 {
+		got = append(got, h.PopMin())
+	}
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("slices must be equal: expected=%v got=%v", expected, got)
+	}
+}
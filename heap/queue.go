@@ -0,0 +1,176 @@
+package heap
+
+import (
+	"context"
+	"sync"
+)
+
+type queueConfig struct {
+	capacity int
+}
+
+// QueueOption configures Queue.
+type QueueOption func(cfg *queueConfig)
+
+// WithCapacity limits the number of elements the queue can hold.
+// Non-positive capacity means the queue is unbounded, which is the default.
+func WithCapacity(capacity int) QueueOption {
+	return func(cfg *queueConfig) {
+		cfg.capacity = capacity
+	}
+}
+
+// Queue is a thread-safe priority queue backed by Heap.
+type Queue[E any] struct {
+	mu       sync.Mutex
+	heap     Heap[E]
+	capacity int
+	closed   bool
+	// changed is closed and replaced every time
+	// an element is pushed or popped or the queue is closed.
+	changed chan struct{}
+}
+
+// NewQueue creates a new Queue with elements of type E
+// with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+func NewQueue[E any](cmp func(a, b E) int, opts ...QueueOption) *Queue[E] {
+	var cfg queueConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Queue[E]{
+		mu: sync.Mutex{},
+		heap: Heap[E]{
+			data: make([]E, 0),
+			cmp:  cmp,
+		},
+		capacity: cfg.capacity,
+		closed:   false,
+		changed:  make(chan struct{}),
+	}
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[E]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Len()
+}
+
+// Push pushes the element x onto the queue.
+// If the queue is full, waits until there is room for the element.
+//
+// Returns ErrClosed if the queue has been closed,
+// or the context error if the context is done before the element is pushed.
+func (q *Queue[E]) Push(ctx context.Context, x E) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if !q.full() {
+			q.heap.Push(x)
+			q.notify()
+			q.mu.Unlock()
+			return nil
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// TryPush pushes the element x onto the queue without waiting.
+//
+// Returns ErrClosed if the queue has been closed,
+// or ErrFull if the queue is full.
+func (q *Queue[E]) TryPush(x E) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if q.full() {
+		return ErrFull
+	}
+	q.heap.Push(x)
+	q.notify()
+	return nil
+}
+
+// Pop removes and returns the minimum element (according to cmp function) from the queue.
+// If the queue is empty, waits until an element is pushed.
+//
+// Elements pushed before the queue has been closed are still returned.
+// Returns ErrClosed if the queue has been closed and is empty,
+// or the context error if the context is done before an element is popped.
+func (q *Queue[E]) Pop(ctx context.Context) (E, error) {
+	for {
+		q.mu.Lock()
+		if q.heap.Len() != 0 {
+			x := q.heap.Pop()
+			q.notify()
+			q.mu.Unlock()
+			return x, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero E
+			return zero, ErrClosed
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var zero E
+			return zero, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// TryPop removes and returns the minimum element (according to cmp function)
+// from the queue without waiting.
+// Returns false if the queue is empty.
+func (q *Queue[E]) TryPop() (x E, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.heap.Len() == 0 {
+		return x, false
+	}
+	x = q.heap.Pop()
+	q.notify()
+	return x, true
+}
+
+// Close closes the queue.
+// Any subsequent or blocked Push returns ErrClosed,
+// while Pop continues to return the remaining elements
+// and returns ErrClosed once the queue is drained.
+func (q *Queue[E]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.notify()
+}
+
+func (q *Queue[E]) full() bool {
+	return q.capacity > 0 && q.heap.Len() >= q.capacity
+}
+
+func (q *Queue[E]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
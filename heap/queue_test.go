@@ -0,0 +1,114 @@
+package heap_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/heap"
+)
+
+func TestQueue_Pop_order(t *testing.T) {
+	q := heap.NewQueue(cmpInt)
+
+	for _, x := range []int{5, 3, 8, 1, 9, 2} {
+		if err := q.Push(context.Background(), x); err != nil {
+			t.Fatalf("Push(): unexpected error: %s", err.Error())
+		}
+	}
+	q.Close()
+
+	got := make([]int, 0, 6)
+	for {
+		x, err := q.Pop(context.Background())
+		if errors.Is(err, heap.ErrClosed) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Pop(): unexpected error: %s", err.Error())
+		}
+		got = append(got, x)
+	}
+
+	if expected := []int{1, 2, 3, 5, 8, 9}; !slices.Equal(expected, got) {
+		t.Errorf("slices must be equal: expected=%v got=%v", expected, got)
+	}
+}
+
+func TestQueue_Pop_blocks(t *testing.T) {
+	q := heap.NewQueue(cmpInt)
+
+	result := make(chan int, 1)
+	go func() {
+		x, err := q.Pop(context.Background())
+		if err != nil {
+			t.Errorf("Pop(): unexpected error: %s", err.Error())
+		}
+		result <- x
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("Pop(): expected to block on empty queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := q.TryPush(42); err != nil {
+		t.Fatalf("TryPush(): unexpected error: %s", err.Error())
+	}
+	if x := <-result; x != 42 {
+		t.Errorf("Pop(): expected=42 got=%d", x)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop(): expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestQueue_capacity(t *testing.T) {
+	q := heap.NewQueue(cmpInt, heap.WithCapacity(2))
+
+	for i := range 2 {
+		if err := q.TryPush(i); err != nil {
+			t.Fatalf("TryPush(): unexpected error: %s", err.Error())
+		}
+	}
+	if err := q.TryPush(2); !errors.Is(err, heap.ErrFull) {
+		t.Errorf("TryPush(): expected heap.ErrFull, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := q.Push(context.Background(), 2); err != nil {
+			t.Errorf("Push(): unexpected error: %s", err.Error())
+		}
+	}()
+
+	if x, ok := q.TryPop(); !ok || x != 0 {
+		t.Errorf("TryPop(): expected=(0, true) got=(%d, %t)", x, ok)
+	}
+	wg.Wait()
+
+	if n := q.Len(); n != 2 {
+		t.Errorf("Len(): expected=2 got=%d", n)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := q.Push(context.Background(), 3); !errors.Is(err, heap.ErrClosed) {
+			t.Errorf("Push(): expected heap.ErrClosed, got %v", err)
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+}
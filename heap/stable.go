@@ -0,0 +1,88 @@
+package heap
+
+type stableEntry[E any] struct {
+	value E
+	seq   uint64
+}
+
+// Stable is a generic min-heap, which pops equal elements
+// in the order they were pushed (first in, first out).
+//
+// Ties are broken by a sequence number assigned to an element on Push,
+// so cmp does not have to be aware of the insertion order.
+type Stable[E any] struct {
+	heap Heap[stableEntry[E]]
+	seq  uint64
+}
+
+// NewStable creates a new Stable with elements of type E
+// with the comparison function cmp.
+// See New for the requirements imposed on cmp.
+func NewStable[E any](cmp func(a, b E) int) *Stable[E] {
+	return &Stable[E]{
+		heap: Heap[stableEntry[E]]{
+			data: make([]stableEntry[E], 0),
+			cmp: func(a, b stableEntry[E]) int {
+				if c := cmp(a.value, b.value); c != 0 {
+					return c
+				}
+				switch {
+				case a.seq < b.seq:
+					return -1
+				case a.seq > b.seq:
+					return 1
+				}
+				return 0
+			},
+		},
+		seq: 0,
+	}
+}
+
+// At returns the element at the index i from the heap.
+func (h *Stable[E]) At(i int) E {
+	return h.heap.At(i).value
+}
+
+// Set changes the element at the index i in the heap.
+// The element keeps its position in the insertion order.
+// Fix should be called afterwards if this change breaks heap ordering.
+func (h *Stable[E]) Set(i int, x E) {
+	h.heap.data[i].value = x
+}
+
+// Len returns the number of elements in the heap.
+func (h *Stable[E]) Len() int {
+	return h.heap.Len()
+}
+
+// Peek returns the minimum element (according to cmp function)
+// without removing it from the heap.
+func (h *Stable[E]) Peek() E {
+	return h.heap.Peek().value
+}
+
+// Push pushes the element x onto the heap.
+func (h *Stable[E]) Push(x E) {
+	h.heap.Push(stableEntry[E]{value: x, seq: h.seq})
+	h.seq++
+}
+
+// Pop removes and returns the minimum element (according to cmp function) from the heap.
+// Among equal elements, the one pushed first is returned.
+// Pop is equivalent to Remove(0).
+func (h *Stable[E]) Pop() E {
+	return h.heap.Pop().value
+}
+
+// Remove removes and returns the element at index i from the heap.
+func (h *Stable[E]) Remove(i int) E {
+	return h.heap.Remove(i).value
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(i) followed by a Push of the new value.
+func (h *Stable[E]) Fix(i int) {
+	h.heap.Fix(i)
+}
@@ -0,0 +1,38 @@
+package heap_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/infastin/gorack/heap"
+)
+
+type job struct {
+	priority int
+	id       int
+}
+
+func TestStable(t *testing.T) {
+	h := heap.NewStable(func(a, b job) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	jobs := make([]job, 0, 100)
+	for i := range 100 {
+		x := job{priority: rand.Intn(5), id: i}
+		jobs = append(jobs, x)
+		h.Push(x)
+	}
+
+	slices.SortStableFunc(jobs, func(a, b job) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+
+	for i := 0; h.Len() > 0; i++ {
+		if x := h.Pop(); x != jobs[i] {
+			t.Errorf("%d.th pop got %v; want %v", i, x, jobs[i])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package lifecycle
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"runtime"
+)
+
+// AbortError is returned from Run when stopping has been aborted.
+type AbortError struct {
+	Cause error
+}
+
+func (e *AbortError) Error() string {
+	return "stop aborted: " + e.Cause.Error()
+}
+
+func (e *AbortError) Unwrap() error {
+	return e.Cause
+}
+
+// Abort stops the lifecycle without waiting for the hooks to stop.
+// The hooks that are being stopped have their contexts canceled,
+// and the remaining hooks are not stopped at all.
+// Run then returns AbortError with the given cause.
+//
+// Does nothing if the lifecycle is not running.
+func (l *Lifecycle) Abort(cause error) {
+	l.mu.Lock()
+	cancel, abort := l.cancel, l.abort
+	l.mu.Unlock()
+
+	if abort == nil {
+		return
+	}
+
+	l.logger.Error("aborting stop", slog.String("cause", cause.Error()))
+	if l.stackDump {
+		l.logger.Error("goroutine stack dump", slog.String("stacks", string(stacks())))
+	}
+
+	err := &AbortError{Cause: cause}
+	cancel(err)
+	abort(err)
+}
+
+// ShutdownSignal returns the hook, which stops the lifecycle when one of the signals is received,
+// and aborts stopping with Abort when one of the signals is received again
+// or is received while the lifecycle is already stopping.
+//
+// The signals are handled until Run returns, even after the hook itself is stopped,
+// so the hook can be appended anywhere.
+func (l *Lifecycle) ShutdownSignal(signals ...os.Signal) Hook {
+	return Hook{
+		Name: "shutdown signal handler",
+		OnStart: func(ctx context.Context, cancel context.CancelCauseFunc) error {
+			l.mu.Lock()
+			done := l.done
+			l.mu.Unlock()
+
+			// The lifecycle is nested and isn't run directly.
+			if done == nil {
+				done = ctx.Done()
+			}
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, signals...)
+
+			go func() {
+				defer signal.Stop(sigCh)
+				stopping := false
+				for {
+					select {
+					case <-done:
+						return
+					case sig := <-sigCh:
+						if stopping || l.getPhase() == PhaseStopping {
+							l.Abort(&SignalError{Signal: sig})
+							return
+						}
+						stopping = true
+						cancel(&SignalError{Signal: sig})
+					}
+				}
+			}()
+
+			return nil
+		},
+	}
+}
+
+func stacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
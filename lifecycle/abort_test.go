@@ -0,0 +1,148 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestLifecycle_Abort(t *testing.T) {
+	lc := lifecycle.New(lifecycle.WithStackDump())
+
+	lc.Append(lifecycle.Hook{
+		Name: "skipped",
+		OnStop: func(ctx context.Context) error {
+			t.Error("expected the hook to not be stopped")
+			return nil
+		},
+	})
+
+	hang := make(chan struct{})
+	defer close(hang)
+
+	stopping := make(chan struct{})
+	lc.Append(lifecycle.Hook{
+		Name: "hung",
+		OnStop: func(ctx context.Context) error {
+			close(stopping)
+			<-hang
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- lc.Run(ctx)
+	}()
+
+	cancel()
+	<-stopping
+
+	errForced := errors.New("forced")
+	lc.Abort(errForced)
+
+	select {
+	case err := <-done:
+		var abortErr *lifecycle.AbortError
+		if !errors.As(err, &abortErr) || !errors.Is(err, errForced) {
+			t.Errorf("expected AbortError, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after Abort")
+	}
+}
+
+func TestLifecycle_ShutdownSignal(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []lifecycle.Option
+		// last makes the handler be appended after the hung hook.
+		last bool
+	}{
+		{
+			name: "first",
+			opts: nil,
+			last: false,
+		},
+		{
+			name: "last",
+			opts: nil,
+			last: true,
+		},
+		{
+			name: "dependency order",
+			opts: []lifecycle.Option{lifecycle.WithDependencyOrder()},
+			last: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := lifecycle.New(tt.opts...)
+
+			if !tt.last {
+				lc.Append(lc.ShutdownSignal(syscall.SIGHUP))
+			}
+
+			hang := make(chan struct{})
+			defer close(hang)
+
+			stopping := make(chan struct{})
+			lc.Append(lifecycle.Hook{
+				Name: "hung",
+				OnStop: func(ctx context.Context) error {
+					close(stopping)
+					<-hang
+					return nil
+				},
+			})
+
+			if tt.last {
+				lc.Append(lc.ShutdownSignal(syscall.SIGHUP))
+			}
+
+			done := make(chan error)
+			go func() {
+				done <- lc.Run(context.Background())
+			}()
+
+			for lc.Status().Phase != lifecycle.PhaseRunning {
+				time.Sleep(time.Millisecond)
+			}
+
+			p, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				t.Fatalf("failed to find process: %s", err.Error())
+			}
+			if err := p.Signal(syscall.SIGHUP); err != nil {
+				t.Skipf("failed to send signal: %s", err.Error())
+			}
+
+			<-stopping
+
+			if err := p.Signal(syscall.SIGHUP); err != nil {
+				t.Fatalf("failed to send signal: %s", err.Error())
+			}
+
+			select {
+			case err := <-done:
+				var abortErr *lifecycle.AbortError
+				if !errors.As(err, &abortErr) {
+					t.Errorf("expected AbortError, got %v", err)
+				}
+				var sigErr *lifecycle.SignalError
+				if !errors.As(err, &sigErr) {
+					t.Errorf("expected SignalError, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected Run to return after the second signal")
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package lifecycle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HookDescription describes a registered hook.
+type HookDescription struct {
+	Name string
+	// Index is the position of the hook in the order hooks were appended.
+	Index int
+	Kind  Kind
+	// StartTimeout and StopTimeout are the timeouts of the hook,
+	// which are zero if the hook has none.
+	StartTimeout time.Duration
+	StopTimeout  time.Duration
+	// DependsOn contains the indices of the hooks the hook depends on,
+	// including the hook started right before it, unless WithDependencyOrder is used.
+	DependsOn []int
+	// Group describes the nested lifecycle, if the hook is a group.
+	Group *Description
+}
+
+// Description describes the hooks of the lifecycle.
+type Description struct {
+	StopTimeout time.Duration
+	// Hooks are ordered in the order they are started.
+	Hooks []HookDescription
+}
+
+// Describe returns the description of the registered hooks.
+// Returns an error if the dependencies of the hooks can't be resolved.
+func (l *Lifecycle) Describe() (*Description, error) {
+	deps, err := l.dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &Description{
+		StopTimeout: l.stopTimeout,
+		Hooks:       make([]HookDescription, 0, len(l.hooks)),
+	}
+
+	for _, i := range order(deps) {
+		hook := &l.hooks[i]
+
+		var group *Description
+		if hook.group != nil {
+			if group, err = hook.group.Describe(); err != nil {
+				return nil, fmt.Errorf("group %s: %w", hookName(hook.name, i), err)
+			}
+		}
+
+		desc.Hooks = append(desc.Hooks, HookDescription{
+			Name:         hook.name,
+			Index:        i,
+			Kind:         hook.kind,
+			StartTimeout: hook.startTimeout,
+			StopTimeout:  hook.stopTimeout,
+			DependsOn:    deps[i],
+			Group:        group,
+		})
+	}
+
+	return desc, nil
+}
+
+// WriteText writes the description as a numbered list of hooks in the order they are started.
+func (d *Description) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "stop timeout: %s\n", d.StopTimeout)
+	d.writeText(bw, "")
+	return bw.Flush()
+}
+
+func (d *Description) writeText(w *bufio.Writer, indent string) {
+	names := d.names()
+
+	for k := range d.Hooks {
+		hook := &d.Hooks[k]
+
+		fmt.Fprintf(w, "%s%d. %s %s", indent, k+1, hook.Kind, names[hook.Index])
+		if hook.StartTimeout > 0 {
+			fmt.Fprintf(w, ", start timeout: %s", hook.StartTimeout)
+		}
+		if hook.StopTimeout > 0 {
+			fmt.Fprintf(w, ", stop timeout: %s", hook.StopTimeout)
+		}
+		if len(hook.DependsOn) != 0 {
+			w.WriteString(", depends on: ")
+			for k, j := range hook.DependsOn {
+				if k != 0 {
+					w.WriteString(", ")
+				}
+				w.WriteString(names[j])
+			}
+		}
+		w.WriteByte('\n')
+
+		if hook.Group != nil {
+			hook.Group.writeText(w, indent+"   ")
+		}
+	}
+}
+
+// WriteDOT writes the description as a graph in the DOT language,
+// where edges go from hooks to the hooks depending on them,
+// and nested lifecycles are drawn as clusters.
+func (d *Description) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph lifecycle {\n")
+	bw.WriteString("\tcompound=true;\n")
+	bw.WriteString("\tnode [shape=box];\n")
+	d.writeDOT(bw, "n", "\t")
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func (d *Description) writeDOT(w *bufio.Writer, prefix, indent string) {
+	names := d.names()
+	id := func(i int) string {
+		return prefix + strconv.Itoa(i)
+	}
+
+	for k := range d.Hooks {
+		hook := &d.Hooks[k]
+
+		if hook.Group == nil {
+			fmt.Fprintf(w, "%s%s [label=%s];\n", indent, id(hook.Index), dotQuote(hook.Kind.String()+" "+names[hook.Index]))
+			continue
+		}
+
+		// Edges of the group are attached to an invisible node clipped by the cluster.
+		fmt.Fprintf(w, "%ssubgraph cluster_%s {\n", indent, id(hook.Index))
+		fmt.Fprintf(w, "%s\tlabel=%s;\n", indent, dotQuote(hook.Kind.String()+" "+names[hook.Index]))
+		fmt.Fprintf(w, "%s\t%s [shape=point, style=invis];\n", indent, id(hook.Index))
+		hook.Group.writeDOT(w, id(hook.Index)+"_", indent+"\t")
+		fmt.Fprintf(w, "%s}\n", indent)
+	}
+
+	for k := range d.Hooks {
+		hook := &d.Hooks[k]
+		for _, j := range hook.DependsOn {
+			fmt.Fprintf(w, "%s%s -> %s", indent, id(j), id(hook.Index))
+
+			attrs := make([]string, 0, 2)
+			if d.isGroup(j) {
+				attrs = append(attrs, "ltail=cluster_"+id(j))
+			}
+			if hook.Group != nil {
+				attrs = append(attrs, "lhead=cluster_"+id(hook.Index))
+			}
+			if len(attrs) != 0 {
+				fmt.Fprintf(w, " [%s]", strings.Join(attrs, ", "))
+			}
+
+			w.WriteString(";\n")
+		}
+	}
+}
+
+// names returns the names of the hooks by their indices.
+func (d *Description) names() []string {
+	names := make([]string, len(d.Hooks))
+	for k := range d.Hooks {
+		hook := &d.Hooks[k]
+		names[hook.Index] = hookName(hook.Name, hook.Index)
+	}
+	return names
+}
+
+func (d *Description) isGroup(i int) bool {
+	for k := range d.Hooks {
+		if d.Hooks[k].Index == i {
+			return d.Hooks[k].Group != nil
+		}
+	}
+	return false
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
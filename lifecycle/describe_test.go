@@ -0,0 +1,90 @@
+package lifecycle_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func newDescribed() *lifecycle.Lifecycle {
+	lc := lifecycle.New(lifecycle.WithDependencyOrder(), lifecycle.WithStopTimeout(time.Minute))
+	lc.Append(lifecycle.Hook{
+		Name:      "server",
+		DependsOn: []string{"db", "module"},
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			return nil
+		},
+	})
+	lc.Append(lifecycle.Hook{
+		Name:         "db",
+		StartTimeout: 5 * time.Second,
+		StopTimeout:  10 * time.Second,
+	})
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.GoFunc(func(ctx context.Context) error {
+			return nil
+		})
+	}, lifecycle.WithStopTimeout(30*time.Second), lifecycle.WithDependsOn("db"))
+	return lc
+}
+
+func TestLifecycle_Describe(t *testing.T) {
+	desc, err := newDescribed().Describe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	if err := desc.WriteText(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `stop timeout: 1m0s
+1. hook "db", start timeout: 5s, stop timeout: 10s
+2. group "module", stop timeout: 30s, depends on: "db"
+   1. actor #0
+3. hook "server", depends on: "db", "module"
+`
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestLifecycle_Describe_dot(t *testing.T) {
+	desc, err := newDescribed().Describe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	if err := desc.WriteDOT(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dot := b.String()
+	for _, line := range []string{
+		`n1 [label="hook \"db\""];`,
+		`subgraph cluster_n2 {`,
+		`n2_0 [label="actor #0"];`,
+		`n1 -> n0;`,
+		`n2 -> n0 [ltail=cluster_n2];`,
+		`n1 -> n2 [lhead=cluster_n2];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("expected DOT to contain %q, got:\n%s", line, dot)
+		}
+	}
+}
+
+func TestLifecycle_Describe_cycle(t *testing.T) {
+	lc := lifecycle.New(lifecycle.WithDependencyOrder())
+	lc.Append(lifecycle.Hook{Name: "a", DependsOn: []string{"b"}})
+	lc.Append(lifecycle.Hook{Name: "b", DependsOn: []string{"a"}})
+
+	if _, err := lc.Describe(); err == nil {
+		t.Error("expected an error")
+	}
+}
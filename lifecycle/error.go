@@ -0,0 +1,91 @@
+package lifecycle
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HookError describes a failure of a hook.
+type HookError struct {
+	Name string
+	// Index is the position of the hook in the order hooks were appended,
+	// which identifies hooks without names.
+	Index    int
+	Kind     Kind
+	Stage    Stage
+	Duration time.Duration
+	Err      error
+}
+
+func (e *HookError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Stage.String())
+	b.WriteByte(' ')
+	b.WriteString(e.Kind.String())
+	b.WriteByte(' ')
+	b.WriteString(hookName(e.Name, e.Index))
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// RunError is returned from Run once the lifecycle has stopped.
+type RunError struct {
+	// Cause is the reason the lifecycle has stopped, which is either
+	// the cause of the context passed to Run, the cause passed to CancelCauseFunc,
+	// SignalError, AbortError or HookError of the failed hook.
+	Cause error
+	// Failures contains the errors of the hooks that have failed
+	// to start or to stop, and of the actors that have exited with an error,
+	// in the order they have failed.
+	Failures []*HookError
+	// Abort is not nil if stopping has been aborted.
+	Abort *AbortError
+}
+
+func (e *RunError) Error() string {
+	errs := e.Unwrap()
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	var b strings.Builder
+	for i, err := range errs {
+		if i != 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the cause, the failures and the abort error,
+// so errors.Is and errors.As can be used to inspect any of them.
+func (e *RunError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures)+2)
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	for _, err := range e.Failures {
+		if err != e.Cause {
+			errs = append(errs, err)
+		}
+	}
+	if e.Abort != nil && e.Abort != e.Cause {
+		errs = append(errs, e.Abort)
+	}
+	return errs
+}
+
+func hookName(name string, i int) string {
+	if name == "" {
+		return "#" + strconv.Itoa(i)
+	}
+	return strconv.Quote(name)
+}
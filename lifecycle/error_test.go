@@ -0,0 +1,82 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestLifecycle_RunError(t *testing.T) {
+	lc := lifecycle.New()
+
+	errStop := errors.New("failed to stop")
+	lc.Append(lifecycle.Hook{
+		Name: "db",
+		OnStop: func(ctx context.Context) error {
+			return errStop
+		},
+	})
+
+	errRun := errors.New("failed to run")
+	lc.Go(lifecycle.Actor{
+		Name: "worker",
+		Run: func(ctx context.Context) error {
+			return errRun
+		},
+	})
+
+	err := lc.Run(context.Background())
+
+	var runErr *lifecycle.RunError
+	if !errors.As(err, &runErr) {
+		t.Fatalf("expected RunError, got %v", err)
+	}
+	if !errors.Is(err, errRun) || !errors.Is(err, errStop) {
+		t.Errorf("expected RunError to wrap the hook errors, got %v", err)
+	}
+
+	var cause *lifecycle.HookError
+	if !errors.As(runErr.Cause, &cause) {
+		t.Fatalf("expected the cause to be HookError, got %v", runErr.Cause)
+	}
+	if cause.Name != "worker" || cause.Kind != lifecycle.KindActor || cause.Stage != lifecycle.StageRun {
+		t.Errorf("unexpected cause: %v", cause)
+	}
+
+	if len(runErr.Failures) != 2 {
+		t.Fatalf("expected 2 failures, got %v", runErr.Failures)
+	}
+	if failure := runErr.Failures[1]; failure.Name != "db" || failure.Stage != lifecycle.StageStop || failure.Index != 0 {
+		t.Errorf("unexpected failure: %v", failure)
+	}
+	if runErr.Abort != nil {
+		t.Errorf("expected stopping to not be aborted, got %v", runErr.Abort)
+	}
+
+	t.Logf("got expected error: %s", err.Error())
+}
+
+func TestLifecycle_RunError_signal(t *testing.T) {
+	lc := lifecycle.New()
+
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			ccf(&lifecycle.SignalError{Signal: testSignal{}})
+			return nil
+		},
+	})
+
+	err := lc.Run(context.Background())
+
+	var sigErr *lifecycle.SignalError
+	if !errors.As(err, &sigErr) {
+		t.Errorf("expected SignalError, got %v", err)
+	}
+}
+
+type testSignal struct{}
+
+func (testSignal) String() string { return "test" }
+func (testSignal) Signal()        {}
@@ -0,0 +1,203 @@
+package lifecycle
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// dependencies returns the indices of the hooks each hook depends on.
+// Returns an error if a hook depends on an unknown or ambiguous name,
+// or if there is a dependency cycle.
+func (l *Lifecycle) dependencies() ([][]int, error) {
+	byName := make(map[string]int, len(l.hooks))
+	for i := range l.hooks {
+		if name := l.hooks[i].name; name != "" {
+			if _, ok := byName[name]; ok {
+				byName[name] = -1
+			} else {
+				byName[name] = i
+			}
+		}
+	}
+
+	deps := make([][]int, len(l.hooks))
+	for i := range l.hooks {
+		hook := &l.hooks[i]
+		for _, name := range hook.dependsOn {
+			j, ok := byName[name]
+			switch {
+			case !ok:
+				return nil, fmt.Errorf("lifecycle: hook %s depends on unknown hook %q", hookName(hook.name, i), name)
+			case j < 0:
+				return nil, fmt.Errorf("lifecycle: hook %s depends on ambiguous hook %q", hookName(hook.name, i), name)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+
+	if cycle := findCycle(deps); cycle != nil {
+		names := make([]string, len(cycle))
+		for k, i := range cycle {
+			names[k] = hookName(l.hooks[i].name, i)
+		}
+		return nil, fmt.Errorf("lifecycle: dependency cycle: %s", strings.Join(names, " -> "))
+	}
+
+	if !l.dependencyOrder {
+		// Hooks are started one by one in the order that satisfies their dependencies,
+		// which follows the order hooks were appended where dependencies allow.
+		ord := order(deps)
+		for k := 1; k < len(ord); k++ {
+			i, prev := ord[k], ord[k-1]
+			if !slices.Contains(deps[i], prev) {
+				deps[i] = append(deps[i], prev)
+			}
+		}
+	}
+
+	return deps, nil
+}
+
+// findCycle returns a dependency cycle as a list of indices,
+// where the first and the last indices are the same,
+// or nil if there is no cycle.
+func findCycle(deps [][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(deps))
+	path := make([]int, 0, len(deps))
+
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range deps[i] {
+			switch state[j] {
+			case visiting:
+				for k := len(path) - 1; k >= 0; k-- {
+					if path[k] == j {
+						return append(path[k:], j)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range deps {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// reverse returns the graph with every edge reversed.
+func reverse(deps [][]int) [][]int {
+	res := make([][]int, len(deps))
+	for i, ds := range deps {
+		for _, j := range ds {
+			res[j] = append(res[j], i)
+		}
+	}
+	return res
+}
+
+// walk calls fn for every node of the acyclic graph once all of its dependencies
+// have been visited, visiting independent nodes concurrently.
+// If fn returns false, no more nodes are visited,
+// although walk still waits for the nodes that are being visited.
+//
+// Returns the nodes for which fn has been called and returned true.
+func walk(deps [][]int, fn func(i int) bool) []bool {
+	type result struct {
+		i  int
+		ok bool
+	}
+
+	pending := make([]int, len(deps))
+	for i, ds := range deps {
+		pending[i] = len(ds)
+	}
+	dependents := reverse(deps)
+
+	results := make(chan result)
+	running := 0
+	visit := func(i int) {
+		running++
+		go func() {
+			results <- result{i: i, ok: fn(i)}
+		}()
+	}
+
+	for i := range deps {
+		if pending[i] == 0 {
+			visit(i)
+		}
+	}
+
+	ok := make([]bool, len(deps))
+	aborted := false
+
+	for running > 0 {
+		r := <-results
+		running--
+		ok[r.i] = r.ok
+		if !r.ok {
+			aborted = true
+		}
+		if aborted {
+			continue
+		}
+		for _, j := range dependents[r.i] {
+			if pending[j]--; pending[j] == 0 {
+				visit(j)
+			}
+		}
+	}
+
+	return ok
+}
+
+// order returns the nodes of the acyclic graph in topological order,
+// preferring the nodes with lower indices.
+func order(deps [][]int) []int {
+	pending := make([]int, len(deps))
+	for i, ds := range deps {
+		pending[i] = len(ds)
+	}
+	dependents := reverse(deps)
+
+	res := make([]int, 0, len(deps))
+	visited := make([]bool, len(deps))
+
+	for len(res) != len(deps) {
+		for i := range deps {
+			if visited[i] || pending[i] != 0 {
+				continue
+			}
+			visited[i] = true
+			res = append(res, i)
+			for _, j := range dependents[i] {
+				pending[j]--
+			}
+			break
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,55 @@
+package lifecycle
+
+import "log/slog"
+
+// Group appends the nested lifecycle, whose hooks are appended by build.
+// The nested lifecycle inherits the stop timeout, the observer and the ordering of l,
+// and logs with the logger of l scoped by the group name. Options override them,
+// and WithDependsOn sets the hooks of l the nested lifecycle depends on.
+//
+// See AppendLifecycle for how the nested lifecycle is run.
+func (l *Lifecycle) Group(name string, build func(child *Lifecycle), opts ...Option) {
+	inherited := []Option{
+		WithStopTimeout(l.stopTimeout),
+		WithLogger(l.logger.With(slog.String("group", name))),
+		WithObserver(l.observer),
+	}
+	if l.dependencyOrder {
+		inherited = append(inherited, WithDependencyOrder())
+	}
+
+	child := New(append(inherited, opts...)...)
+	build(child)
+
+	l.AppendLifecycle(name, child)
+}
+
+// AppendLifecycle appends the child lifecycle as a single hook,
+// which starts all hooks of the child and stops them within the stop timeout of the child.
+// Hooks of the child receive the cancel function of l,
+// and failures of the child are reported as failures of the group.
+// The group depends on the hooks of l set with WithDependsOn for the child.
+//
+// The child must not be run on its own or be appended more than once.
+func (l *Lifecycle) AppendLifecycle(name string, child *Lifecycle) {
+	hook := hook{
+		name:        name,
+		kind:        KindGroup,
+		dependsOn:   child.dependsOn,
+		stopTimeout: child.stopTimeout,
+		group:       child,
+	}
+
+	logger := l.logger
+	if name != "" {
+		logger = logger.With(slog.String("name", name))
+	}
+
+	hook.onReload = reloadFunc(logger, child.Reload)
+
+	child.mu.Lock()
+	child.parent, child.index = l, len(l.hooks)
+	child.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook)
+}
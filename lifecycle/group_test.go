@@ -0,0 +1,274 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestLifecycle_Group(t *testing.T) {
+	lc := lifecycle.New()
+
+	var mu sync.Mutex
+	calls := make([]string, 0)
+	record := func(call string) {
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+	}
+
+	hook := func(name string) lifecycle.Hook {
+		return lifecycle.Hook{
+			Name: name,
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				record("start " + name)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				record("stop " + name)
+				return nil
+			},
+		}
+	}
+
+	lc.Append(hook("a"))
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.Append(hook("b"))
+		child.Go(lifecycle.Actor{
+			Name: "c",
+			Run: func(ctx context.Context) error {
+				record("start c")
+				<-ctx.Done()
+				return nil
+			},
+			Shutdown: func(ctx context.Context) error {
+				record("stop c")
+				return nil
+			},
+		})
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "d",
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			if status := lc.Status(); status.Hooks[1].Kind != lifecycle.KindGroup ||
+				status.Hooks[1].State != lifecycle.StateRunning {
+				t.Errorf("expected the group to be running, got %v", status.Hooks[1])
+			}
+			ccf(nil)
+			return nil
+		},
+	})
+
+	err := lc.Run(context.Background())
+
+	var runErr *lifecycle.RunError
+	if !errors.As(err, &runErr) || len(runErr.Failures) != 0 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The actor is started concurrently with the stop hook of the group,
+	// so only its relative position is checked.
+	idx := slices.Index(calls, "start c")
+	if idx < 1 {
+		t.Fatalf("expected the actor to be started after the hook, got %v", calls)
+	}
+	calls = slices.Delete(calls, idx, idx+1)
+
+	expected := []string{"start a", "start b", "stop c", "stop b", "stop a"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestLifecycle_Group_failure(t *testing.T) {
+	lc := lifecycle.New()
+
+	errStart := errors.New("failed to start")
+	stopped := false
+
+	lc.Append(lifecycle.Hook{
+		Name: "a",
+		OnStop: func(ctx context.Context) error {
+			stopped = true
+			return nil
+		},
+	})
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.Append(lifecycle.Hook{
+			Name: "b",
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				return errStart
+			},
+		})
+		child.Append(lifecycle.Hook{
+			Name: "c",
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				t.Error("expected the hook to not be started")
+				return nil
+			},
+		})
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, errStart) {
+		t.Fatalf("expected the start error, got %v", err)
+	}
+	if !stopped {
+		t.Error("expected the hook before the group to be stopped")
+	}
+
+	var runErr *lifecycle.RunError
+	if !errors.As(err, &runErr) || len(runErr.Failures) != 1 {
+		t.Fatalf("expected a single failure, got %v", err)
+	}
+
+	failure := runErr.Failures[0]
+	if failure.Name != "module" || failure.Kind != lifecycle.KindGroup || failure.Stage != lifecycle.StageStart {
+		t.Errorf("unexpected failure: %v", failure)
+	}
+
+	var inner *lifecycle.HookError
+	if !errors.As(failure.Err, &inner) || inner.Name != "b" {
+		t.Errorf("expected the failure to wrap the error of the hook, got %v", failure.Err)
+	}
+
+	t.Logf("got expected error: %s", err.Error())
+}
+
+func TestLifecycle_Group_failureStopsStartedHooks(t *testing.T) {
+	lc := lifecycle.New()
+
+	errStart := errors.New("failed to start")
+	stopped := false
+
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.Append(lifecycle.Hook{
+			Name: "ok",
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				stopped = true
+				return nil
+			},
+		})
+		child.Append(lifecycle.Hook{
+			Name: "bad",
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				return errStart
+			},
+		})
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, errStart) {
+		t.Fatalf("expected the start error, got %v", err)
+	}
+	if !stopped {
+		t.Error("expected the started hook of the group to be stopped")
+	}
+
+	var runErr *lifecycle.RunError
+	if !errors.As(err, &runErr) || len(runErr.Failures) != 1 {
+		t.Errorf("expected a single failure, got %v", err)
+	}
+	if state := lc.Status().Hooks[0].State; state != lifecycle.StateFailed {
+		t.Errorf("expected the group to stay failed, got %s", state)
+	}
+}
+
+func TestLifecycle_Group_dependsOn(t *testing.T) {
+	lc := lifecycle.New(lifecycle.WithDependencyOrder())
+
+	release := make(chan struct{})
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.Append(lifecycle.Hook{
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				select {
+				case <-release:
+				default:
+					t.Error("expected the group to be started after its dependency")
+				}
+				ccf(nil)
+				return nil
+			},
+		})
+	}, lifecycle.WithDependsOn("db"))
+	lc.Append(lifecycle.Hook{
+		Name: "db",
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+			return nil
+		},
+	})
+
+	lc.Run(context.Background())
+}
+
+func TestLifecycle_Group_stopTimeout(t *testing.T) {
+	lc := lifecycle.New()
+
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			ccf(nil)
+			return nil
+		},
+	})
+	lc.Group("module", func(child *lifecycle.Lifecycle) {
+		child.Append(lifecycle.Hook{
+			Name: "slow",
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+	}, lifecycle.WithStopTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	err := lc.Run(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the group to be stopped within its stop timeout, took %s", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the stop hook to time out, got %v", err)
+	}
+
+	var runErr *lifecycle.RunError
+	if !errors.As(err, &runErr) || len(runErr.Failures) != 1 {
+		t.Fatalf("expected a single failure, got %v", err)
+	}
+	if failure := runErr.Failures[0]; failure.Name != "module" || failure.Stage != lifecycle.StageStop {
+		t.Errorf("unexpected failure: %v", failure)
+	}
+}
+
+func TestLifecycle_AppendLifecycle_unknownDependency(t *testing.T) {
+	child := lifecycle.New(lifecycle.WithDependsOn("a"))
+	child.Append(lifecycle.Hook{
+		Name:      "b",
+		DependsOn: []string{"unknown"},
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			t.Error("expected the hook to not be started")
+			return nil
+		},
+	})
+
+	lc := lifecycle.New()
+	lc.Append(lifecycle.Hook{Name: "a"})
+	lc.AppendLifecycle("module", child)
+
+	err := lc.Run(context.Background())
+
+	var runErr *lifecycle.RunError
+	if err == nil || errors.As(err, &runErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	t.Logf("got expected error: %s", err.Error())
+}
@@ -0,0 +1,73 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type hookStatusJSON struct {
+	Name  string `json:"name,omitempty"`
+	Kind  Kind   `json:"kind"`
+	State State  `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+type statusJSON struct {
+	Status string           `json:"status"`
+	Phase  Phase            `json:"phase"`
+	Hooks  []hookStatusJSON `json:"hooks"`
+}
+
+// HealthHandler returns http handler that provides /livez and /readyz routes,
+// which render the status of the lifecycle as JSON.
+// The routes respond with 200 OK when the lifecycle is live or ready respectively,
+// and with 503 Service Unavailable otherwise.
+func (l *Lifecycle) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		status := l.Status()
+
+		var ok bool
+		switch r.URL.Path {
+		case "/livez":
+			ok = status.Live()
+		case "/readyz":
+			ok = status.Ready()
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		res := statusJSON{
+			Status: "ok",
+			Phase:  status.Phase,
+			Hooks:  make([]hookStatusJSON, len(status.Hooks)),
+		}
+		if !ok {
+			res.Status = "unavailable"
+		}
+		for i, hook := range status.Hooks {
+			res.Hooks[i] = hookStatusJSON{
+				Name:  hook.Name,
+				Kind:  hook.Kind,
+				State: hook.State,
+			}
+			if hook.Err != nil {
+				res.Hooks[i].Error = hook.Err.Error()
+			}
+		}
+
+		code := http.StatusOK
+		if !ok {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(res)
+	})
+}
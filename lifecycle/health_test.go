@@ -0,0 +1,99 @@
+package lifecycle_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func checkHealth(t *testing.T, h http.Handler, path string, expectedCode int, expectedPhase string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	if rec.Code != expectedCode {
+		t.Errorf("%s: expected status code %d, got %d", path, expectedCode, rec.Code)
+	}
+
+	var body struct {
+		Phase string `json:"phase"`
+		Hooks []struct {
+			Name  string `json:"name"`
+			Kind  string `json:"kind"`
+			State string `json:"state"`
+		} `json:"hooks"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Errorf("%s: failed to decode body: %s", path, err.Error())
+		return
+	}
+
+	if body.Phase != expectedPhase {
+		t.Errorf("%s: expected phase %s, got %s", path, expectedPhase, body.Phase)
+	}
+}
+
+func TestLifecycle_HealthHandler(t *testing.T) {
+	lc := lifecycle.New()
+	h := lc.HealthHandler()
+
+	checkHealth(t, h, "/livez", http.StatusOK, "idle")
+	checkHealth(t, h, "/readyz", http.StatusServiceUnavailable, "idle")
+
+	running := make(chan struct{})
+	lc.Append(lifecycle.Hook{Name: "db"})
+	lc.Go(lifecycle.Actor{
+		Name: "worker",
+		Run: func(ctx context.Context) error {
+			close(running)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- lc.Run(ctx)
+	}()
+
+	<-running
+	for lc.Status().Phase != lifecycle.PhaseRunning {
+		time.Sleep(time.Millisecond)
+	}
+
+	checkHealth(t, h, "/livez", http.StatusOK, "running")
+	checkHealth(t, h, "/readyz", http.StatusOK, "running")
+
+	status := lc.Status()
+	if len(status.Hooks) != 2 {
+		t.Fatalf("expected 2 hooks, got %d", len(status.Hooks))
+	}
+	if hook := status.Hooks[1]; hook.Kind != lifecycle.KindActor || hook.State != lifecycle.StateRunning {
+		t.Errorf("expected running actor, got %s %s", hook.Kind, hook.State)
+	}
+
+	cancel()
+	<-done
+
+	checkHealth(t, h, "/livez", http.StatusServiceUnavailable, "stopped")
+	checkHealth(t, h, "/readyz", http.StatusServiceUnavailable, "stopped")
+
+	for _, hook := range lc.Status().Hooks {
+		if hook.State != lifecycle.StateStopped {
+			t.Errorf("expected hook %s to be stopped, got %s", hook.Name, hook.State)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status code %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -2,27 +2,83 @@ package lifecycle
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"time"
 )
 
 type hook struct {
+	name           string
+	kind           Kind
+	dependsOn      []string
+	startTimeout   time.Duration
+	stopTimeout    time.Duration
 	startCtx       context.Context
 	cancelStartCtx context.CancelFunc
 	onStart        func(context.Context, context.CancelCauseFunc) error
 	onStop         func(context.Context) error
+	onReload       func(context.Context) error
+	// group is the nested lifecycle, which is started and stopped as the hook.
+	group *Lifecycle
+	// state and err are protected by the mutex of the lifecycle.
+	state State
+	err   error
 }
 
 type Hook struct {
-	Name    string
-	OnStart func(context.Context, context.CancelCauseFunc) error
-	OnStop  func(context.Context) error
+	Name      string
+	DependsOn []string
+	// StartTimeout, if positive, limits the time OnStart can take.
+	// The timeout doesn't affect the context passed to OnStart,
+	// which lives until the hook is stopped, as without the timeout.
+	// If OnStart doesn't return in time, the hook fails to start,
+	// and the context is canceled.
+	StartTimeout time.Duration
+	// StopTimeout, if positive, limits the time OnStop can take.
+	// It can't exceed the stop timeout of the lifecycle.
+	StopTimeout time.Duration
+	OnStart     func(context.Context, context.CancelCauseFunc) error
+	OnStop      func(context.Context) error
+	// OnReload, if not nil, is called when the lifecycle is reloaded.
+	OnReload func(context.Context) error
 }
 
+// Actor has no start timeout, since it is considered started as soon as Run is called.
+// Use ReadyActor with StartTimeout to limit the time it takes Run to become ready.
 type Actor struct {
-	Name     string
+	Name      string
+	DependsOn []string
+	// StopTimeout, if positive, limits the time Shutdown can take.
+	// It can't exceed the stop timeout of the lifecycle.
+	StopTimeout time.Duration
+	// Restart, if not nil, makes Run be restarted when it returns an error.
+	// Otherwise, an error returned from Run stops the lifecycle.
+	Restart  *RestartPolicy
 	Run      func(context.Context) error
 	Shutdown func(context.Context) error
+	// Reload, if not nil, is called when the lifecycle is reloaded.
+	Reload func(context.Context) error
+}
+
+// ReadyActor is an Actor, which signals that it is ready
+// by calling the ready function passed to Run.
+type ReadyActor struct {
+	Name      string
+	DependsOn []string
+	// StartTimeout, if positive, limits the time it can take Run to call ready.
+	StartTimeout time.Duration
+	// StopTimeout, if positive, limits the time Shutdown can take.
+	// It can't exceed the stop timeout of the lifecycle.
+	StopTimeout time.Duration
+	// Restart, if not nil, makes Run be restarted when it returns an error.
+	// Otherwise, an error returned from Run stops the lifecycle.
+	Restart  *RestartPolicy
+	Run      func(ctx context.Context, ready func()) error
+	Shutdown func(context.Context) error
+	// Reload, if not nil, is called when the lifecycle is reloaded.
+	Reload func(context.Context) error
 }
 
 type SignalError struct {
@@ -52,3 +108,28 @@ func Signal(signals ...os.Signal) Hook {
 		},
 	}
 }
+
+// callTimeout calls fn and waits for it to return or for ctx to be done,
+// whichever happens first.
+func callTimeout(ctx context.Context, fn func(context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+		if err == nil || ctx.Err() == nil {
+			return err
+		}
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+	}
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("interrupted: %w", err)
+	}
+
+	return fmt.Errorf("timed out: %w", err)
+}
@@ -3,19 +3,30 @@ package lifecycle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"sync"
 	"time"
 )
 
 type config struct {
-	stopTimeout time.Duration
-	logger      *slog.Logger
+	stopTimeout     time.Duration
+	logger          *slog.Logger
+	dependencyOrder bool
+	observer        Observer
+	stackDump       bool
+	dependsOn       []string
 }
 
 func defaultConfig() config {
 	return config{
-		stopTimeout: time.Minute,
-		logger:      slog.New(slog.DiscardHandler),
+		stopTimeout:     time.Minute,
+		logger:          slog.New(slog.DiscardHandler),
+		dependencyOrder: false,
+		observer:        nopObserver{},
+		stackDump:       false,
+		dependsOn:       nil,
 	}
 }
 
@@ -33,10 +44,62 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithDependencyOrder makes hooks be ordered only by their dependencies.
+// Hooks that don't depend on each other are started and stopped concurrently.
+//
+// By default, hooks are started one by one in the order they were appended,
+// except that a hook is started after the hooks it depends on,
+// and stopped one by one in reverse order.
+func WithDependencyOrder() Option {
+	return func(cfg *config) {
+		cfg.dependencyOrder = true
+	}
+}
+
+// WithObserver sets the observer, which is notified about the execution of hooks.
+func WithObserver(observer Observer) Option {
+	return func(cfg *config) {
+		cfg.observer = observer
+	}
+}
+
+// WithStackDump makes the lifecycle log stack traces of all goroutines
+// when stopping is aborted, which helps to find out what prevents it from stopping.
+func WithStackDump() Option {
+	return func(cfg *config) {
+		cfg.stackDump = true
+	}
+}
+
+// WithDependsOn sets the names of the hooks the lifecycle depends on
+// when it is nested in another lifecycle with Group or AppendLifecycle.
+func WithDependsOn(names ...string) Option {
+	return func(cfg *config) {
+		cfg.dependsOn = names
+	}
+}
+
 type Lifecycle struct {
-	stopTimeout time.Duration
-	logger      *slog.Logger
-	hooks       []hook
+	stopTimeout     time.Duration
+	logger          *slog.Logger
+	dependencyOrder bool
+	observer        Observer
+	stackDump       bool
+	dependsOn       []string
+	hooks           []hook
+	deps            [][]int
+	started         []bool
+	// parent is the lifecycle the lifecycle is nested in as the hook with the index.
+	parent   *Lifecycle
+	index    int
+	mu       sync.Mutex
+	phase    Phase
+	failures []*HookError
+	cancel   context.CancelCauseFunc
+	abort    context.CancelCauseFunc
+	// done is closed once Run returns or stopping is aborted.
+	done     <-chan struct{}
+	reloadMu sync.Mutex
 }
 
 func New(opts ...Option) *Lifecycle {
@@ -46,14 +109,35 @@ func New(opts ...Option) *Lifecycle {
 	}
 
 	return &Lifecycle{
-		stopTimeout: cfg.stopTimeout,
-		logger:      cfg.logger,
-		hooks:       make([]hook, 0),
+		stopTimeout:     cfg.stopTimeout,
+		logger:          cfg.logger,
+		dependencyOrder: cfg.dependencyOrder,
+		observer:        cfg.observer,
+		stackDump:       cfg.stackDump,
+		dependsOn:       cfg.dependsOn,
+		hooks:           make([]hook, 0),
+		deps:            nil,
+		started:         nil,
+		parent:          nil,
+		index:           0,
+		mu:              sync.Mutex{},
+		phase:           PhaseIdle,
+		failures:        nil,
+		cancel:          nil,
+		abort:           nil,
+		done:            nil,
+		reloadMu:        sync.Mutex{},
 	}
 }
 
 func (l *Lifecycle) Append(h Hook) {
-	hook := hook{}
+	hook := hook{
+		name:         h.Name,
+		kind:         KindHook,
+		dependsOn:    h.DependsOn,
+		startTimeout: h.StartTimeout,
+		stopTimeout:  h.StopTimeout,
+	}
 
 	logger := l.logger
 	if h.Name != "" {
@@ -63,7 +147,9 @@ func (l *Lifecycle) Append(h Hook) {
 	if h.OnStart != nil {
 		hook.onStart = func(ctx context.Context, cancel context.CancelCauseFunc) error {
 			logger.Info("running start hook")
-			err := h.OnStart(ctx, cancel)
+			err := protect(func() error {
+				return h.OnStart(ctx, cancel)
+			})
 			if err != nil {
 				logger.Error("start hook ran with failure", slog.String("error", err.Error()))
 			} else {
@@ -76,7 +162,9 @@ func (l *Lifecycle) Append(h Hook) {
 	if h.OnStop != nil {
 		hook.onStop = func(ctx context.Context) error {
 			logger.Info("running stop hook")
-			err := h.OnStop(ctx)
+			err := protect(func() error {
+				return h.OnStop(ctx)
+			})
 			if err != nil {
 				logger.Error("stop hook ran with failure", slog.String("error", err.Error()))
 			} else {
@@ -86,37 +174,126 @@ func (l *Lifecycle) Append(h Hook) {
 		}
 	}
 
+	if h.OnReload != nil {
+		hook.onReload = reloadFunc(logger, h.OnReload)
+	}
+
 	l.hooks = append(l.hooks, hook)
 }
 
 func (l *Lifecycle) Go(actor Actor) {
-	hook := hook{}
+	var run func(context.Context, func()) error
+	if actor.Run != nil {
+		run = func(ctx context.Context, ready func()) error {
+			ready()
+			return actor.Run(ctx)
+		}
+	}
+
+	l.GoReady(ReadyActor{
+		Name:        actor.Name,
+		DependsOn:   actor.DependsOn,
+		StopTimeout: actor.StopTimeout,
+		Restart:     actor.Restart,
+		Run:         run,
+		Shutdown:    actor.Shutdown,
+		Reload:      actor.Reload,
+	})
+}
+
+// GoReady appends the actor, whose Run is started in a separate goroutine.
+// Hooks depending on the actor are not started until the actor calls ready,
+// or until Run returns, in which case its error is treated as a start error.
+func (l *Lifecycle) GoReady(actor ReadyActor) {
+	hook := hook{
+		name:         actor.Name,
+		kind:         KindActor,
+		dependsOn:    actor.DependsOn,
+		startTimeout: actor.StartTimeout,
+		stopTimeout:  actor.StopTimeout,
+	}
 
 	logger := l.logger
 	if actor.Name != "" {
 		logger = logger.With(slog.String("name", actor.Name))
 	}
 
+	i := len(l.hooks)
+
 	if actor.Run != nil {
 		hook.onStart = func(ctx context.Context, cancel context.CancelCauseFunc) error {
+			readyCh := make(chan struct{})
+			ready := sync.OnceFunc(func() {
+				close(readyCh)
+			})
+
+			done := make(chan error, 1)
 			go func() {
-				logger.Info("running start hook")
-				err := actor.Run(ctx)
-				if err != nil {
-					logger.Error("start hook ran with failure", slog.String("error", err.Error()))
-				} else {
-					logger.Info("start hook ran successfully")
+				var lastErr *HookError
+				restarted := false
+				err := supervise(ctx, logger, actor.Restart, func(ctx context.Context) error {
+					if restarted {
+						l.setState(i, StateRunning, nil)
+					}
+					logger.Info("running start hook")
+					end := l.observe(i, StageRun)
+					err := protect(func() error {
+						return actor.Run(ctx, ready)
+					})
+					lastErr = end(err)
+					if err != nil {
+						logger.Error("start hook ran with failure", slog.String("error", err.Error()))
+						l.setState(i, StateRestarting, err)
+					} else {
+						logger.Info("start hook ran successfully")
+					}
+					restarted = true
+					return err
+				})
+
+				done <- err
+
+				if err == nil || ctx.Err() != nil {
+					l.mu.Lock()
+					// The actor may have failed to become ready in time.
+					if l.hooks[i].state != StateFailed {
+						l.hooks[i].state = StateStopped
+					}
+					l.mu.Unlock()
+					cancel(err)
+					return
+				}
+
+				l.setState(i, StateFailed, err)
+
+				if isClosed(readyCh) {
+					// Otherwise, the error is returned as a start error.
+					lastErr.Err = err
+					l.fail(lastErr)
+					cancel(lastErr)
 				}
-				cancel(err)
 			}()
-			return nil
+
+			select {
+			case <-readyCh:
+				return nil
+			case err := <-done:
+				if isClosed(readyCh) {
+					return nil
+				}
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
 	}
 
 	if actor.Shutdown != nil {
 		hook.onStop = func(ctx context.Context) error {
 			logger.Info("running stop hook")
-			err := actor.Shutdown(ctx)
+			err := protect(func() error {
+				return actor.Shutdown(ctx)
+			})
 			if err != nil {
 				logger.Error("stop hook ran with failure", slog.String("error", err.Error()))
 			} else {
@@ -126,6 +303,10 @@ func (l *Lifecycle) Go(actor Actor) {
 		}
 	}
 
+	if actor.Reload != nil {
+		hook.onReload = reloadFunc(logger, actor.Reload)
+	}
+
 	l.hooks = append(l.hooks, hook)
 }
 
@@ -138,44 +319,308 @@ func (l *Lifecycle) Run(ctx context.Context) error {
 		return nil
 	}
 
+	start := time.Now()
+	err := l.run(ctx)
+	l.observer.RunEnd(RunEvent{
+		Duration: time.Since(start),
+		Err:      err,
+	})
+
+	return err
+}
+
+func (l *Lifecycle) run(ctx context.Context) error {
+	if err := l.prepare(); err != nil {
+		return err
+	}
+
 	noCancelCtx := context.WithoutCancel(ctx)
 
 	hooksCtx, hooksCancel := context.WithCancelCause(ctx)
 	defer hooksCancel(nil)
 
-	k := 0
-	for ; k < len(l.hooks); k++ {
-		hook := &l.hooks[k]
-		if hook.onStart == nil {
+	abortCtx, abort := context.WithCancelCause(noCancelCtx)
+	defer abort(nil)
+
+	l.mu.Lock()
+	l.cancel, l.abort, l.done = hooksCancel, abort, abortCtx.Done()
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		l.cancel, l.abort, l.done = nil, nil, nil
+		l.mu.Unlock()
+	}()
+
+	_ = l.start(noCancelCtx, hooksCancel, abortCtx)
+
+	<-hooksCtx.Done()
+
+	stopCtx, cancel := context.WithTimeout(abortCtx, l.stopTimeout)
+	defer cancel()
+
+	_ = l.stop(stopCtx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	runErr := &RunError{
+		Cause:    context.Cause(hooksCtx),
+		Failures: l.failures,
+		Abort:    nil,
+	}
+	errors.As(context.Cause(abortCtx), &runErr.Abort)
+
+	return runErr
+}
+
+// prepare resolves the dependencies of the hooks, including the hooks of nested lifecycles.
+func (l *Lifecycle) prepare() error {
+	deps, err := l.dependencies()
+	if err != nil {
+		return err
+	}
+
+	for i := range l.hooks {
+		hook := &l.hooks[i]
+		if hook.group == nil {
 			continue
 		}
-		hook.startCtx, hook.cancelStartCtx = context.WithCancel(noCancelCtx)
-		if err := hook.onStart(hook.startCtx, hooksCancel); err != nil {
-			hooksCancel(err)
-			break
+		if err := hook.group.prepare(); err != nil {
+			return fmt.Errorf("group %s: %w", hookName(hook.name, i), err)
 		}
 	}
 
-	<-hooksCtx.Done()
+	l.mu.Lock()
+	l.deps = deps
+	l.mu.Unlock()
+
+	return nil
+}
 
-	errs := make([]error, 0, len(l.hooks)+1)
-	errs = append(errs, context.Cause(hooksCtx))
+// start starts the hooks in dependency order until one of them fails to start
+// or stopCtx is done, passing ctx to them.
+// Returns the error of the hook that has failed to start first.
+func (l *Lifecycle) start(ctx context.Context, cancel context.CancelCauseFunc, stopCtx context.Context) error {
+	l.mu.Lock()
+	l.phase = PhaseStarting
+	l.failures = make([]*HookError, 0)
+	l.mu.Unlock()
 
-	stopCtx, cancel := context.WithTimeout(noCancelCtx, l.stopTimeout)
-	defer cancel()
+	var mu sync.Mutex
+	var firstErr error
 
-	for i := k - 1; i >= 0; i-- {
-		hook := &l.hooks[i]
-		if hook.onStart != nil {
-			hook.cancelStartCtx()
+	started := walk(l.deps, func(i int) bool {
+		if stopCtx.Err() != nil {
+			return false
 		}
-		if hook.onStop == nil {
-			continue
+		if err := l.startHook(i, ctx, cancel); err != nil {
+			cancel(err)
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+			return false
 		}
-		if err := hook.onStop(stopCtx); err != nil {
-			errs = append(errs, err)
+		return true
+	})
+
+	l.mu.Lock()
+	if !slices.Contains(started, false) {
+		l.phase = PhaseRunning
+	}
+	l.mu.Unlock()
+
+	for i := range l.hooks {
+		// A group that has failed to start may have started some of its hooks,
+		// so it must still be stopped to stop them.
+		if group := l.hooks[i].group; group != nil && !started[i] && slices.Contains(group.started, true) {
+			started[i] = true
 		}
 	}
 
+	l.mu.Lock()
+	l.started = started
+	l.mu.Unlock()
+
+	return firstErr
+}
+
+// stop stops the started hooks in reverse dependency order.
+// If ctx is canceled by Abort, the remaining hooks are not stopped.
+// Returns the errors of the hooks that have failed to stop.
+func (l *Lifecycle) stop(ctx context.Context) error {
+	l.setPhase(PhaseStopping)
+
+	var mu sync.Mutex
+	errs := make([]error, 0)
+
+	walk(reverse(l.deps), func(i int) bool {
+		if !l.started[i] {
+			return true
+		}
+		if isAborted(ctx) {
+			// Don't even try to stop the remaining hooks.
+			l.skipHook(i)
+			return true
+		}
+		if err := l.stopHook(i, ctx); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+		return true
+	})
+
+	l.setPhase(PhaseStopped)
+
 	return errors.Join(errs...)
 }
+
+func (l *Lifecycle) startHook(i int, ctx context.Context, cancel context.CancelCauseFunc) error {
+	hook := &l.hooks[i]
+	if hook.onStart == nil && hook.group == nil {
+		l.setState(i, StateRunning, nil)
+		return nil
+	}
+
+	l.setState(i, StateStarting, nil)
+	end := l.observe(i, StageStart)
+
+	hook.startCtx, hook.cancelStartCtx = context.WithCancel(ctx)
+
+	var err error
+	switch {
+	case hook.group != nil:
+		// Hooks of the nested lifecycle are stopped one by one,
+		// so their contexts must not be canceled all at once.
+		err = hook.group.start(context.WithoutCancel(hook.startCtx), cancel, hook.startCtx)
+	case hook.startTimeout <= 0:
+		err = hook.onStart(hook.startCtx, cancel)
+	default:
+		waitCtx, cancelWait := context.WithTimeout(hook.startCtx, hook.startTimeout)
+		defer cancelWait()
+
+		err = callTimeout(waitCtx, func(context.Context) error {
+			return hook.onStart(hook.startCtx, cancel)
+		})
+	}
+
+	if err := end(err); err != nil {
+		l.setState(i, StateFailed, err.Err)
+		// Failed hooks are never stopped, so whatever OnStart
+		// has left running must be canceled here.
+		hook.cancelStartCtx()
+		// Failures of the nested lifecycle have already been reported.
+		if hook.group == nil {
+			l.fail(err)
+		}
+		return err
+	}
+
+	l.mu.Lock()
+	// Actor may have already exited.
+	if hook.state == StateStarting {
+		hook.state = StateRunning
+	}
+	l.mu.Unlock()
+
+	return nil
+}
+
+func (l *Lifecycle) stopHook(i int, ctx context.Context) error {
+	hook := &l.hooks[i]
+
+	l.mu.Lock()
+	if hook.state != StateFailed {
+		hook.state = StateStopping
+	}
+	l.mu.Unlock()
+
+	if hook.cancelStartCtx != nil {
+		hook.cancelStartCtx()
+	}
+
+	if hook.onStop != nil || hook.group != nil {
+		if hook.stopTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, hook.stopTimeout)
+			defer cancel()
+		}
+
+		end := l.observe(i, StageStop)
+
+		var err error
+		if hook.group != nil {
+			// Every hook of the nested lifecycle is stopped with the timeout on its own.
+			err = hook.group.stop(ctx)
+		} else {
+			err = callTimeout(ctx, hook.onStop)
+		}
+
+		if err := end(err); err != nil {
+			l.setState(i, StateFailed, err.Err)
+			if hook.group == nil {
+				l.fail(err)
+			}
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	if hook.state == StateStopping {
+		hook.state = StateStopped
+	}
+	l.mu.Unlock()
+
+	return nil
+}
+
+// skipHook cancels the context of the started hook without stopping it.
+func (l *Lifecycle) skipHook(i int) {
+	hook := &l.hooks[i]
+	if hook.cancelStartCtx != nil {
+		hook.cancelStartCtx()
+	}
+	if hook.group != nil {
+		for j, started := range hook.group.started {
+			if started {
+				hook.group.skipHook(j)
+			}
+		}
+		hook.group.setPhase(PhaseStopped)
+	}
+}
+
+func (l *Lifecycle) fail(err *HookError) {
+	l.mu.Lock()
+	l.failures = append(l.failures, err)
+	parent, index := l.parent, l.index
+	l.mu.Unlock()
+
+	if parent != nil {
+		parent.fail(&HookError{
+			Name:     parent.hooks[index].name,
+			Index:    index,
+			Kind:     KindGroup,
+			Stage:    err.Stage,
+			Duration: err.Duration,
+			Err:      err,
+		})
+	}
+}
+
+func isAborted(ctx context.Context) bool {
+	var abortErr *AbortError
+	return ctx.Err() != nil && errors.As(context.Cause(ctx), &abortErr)
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
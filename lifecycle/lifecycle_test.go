@@ -2,7 +2,10 @@ package lifecycle_test
 
 import (
 	"context"
+	"errors"
 	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -117,3 +120,267 @@ func FuzzLifecycle_stopOrder(f *testing.F) {
 		}
 	})
 }
+
+func TestLifecycle_dependencyOrder(t *testing.T) {
+	lc := lifecycle.New(lifecycle.WithDependencyOrder())
+
+	var mu sync.Mutex
+	events := make([]string, 0, 8)
+	record := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+
+	// Both a and b must be started before any of them returns,
+	// since they don't depend on each other.
+	var startWg sync.WaitGroup
+	startWg.Add(2)
+
+	for _, name := range []string{"a", "b"} {
+		lc.Append(lifecycle.Hook{
+			Name:      name,
+			DependsOn: []string{"db"},
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				startWg.Done()
+				startWg.Wait()
+				record("start " + name)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				record("stop " + name)
+				return nil
+			},
+		})
+	}
+
+	lc.Append(lifecycle.Hook{
+		Name: "db",
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			record("start db")
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			record("stop db")
+			return nil
+		},
+	})
+
+	lc.Append(lifecycle.Hook{
+		Name:      "server",
+		DependsOn: []string{"a", "b"},
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			record("start server")
+			ccf(nil)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			record("stop server")
+			return nil
+		},
+	})
+
+	if err := lc.Run(context.Background()); err == nil {
+		t.Error("expected an error")
+		return
+	}
+
+	if len(events) != 8 {
+		t.Fatalf("expected 8 events, got %v", events)
+	}
+	if events[0] != "start db" || events[3] != "start server" {
+		t.Errorf("unexpected start order: %v", events[:4])
+	}
+	if events[4] != "stop server" || events[7] != "stop db" {
+		t.Errorf("unexpected stop order: %v", events[4:])
+	}
+}
+
+func TestLifecycle_forwardDependency(t *testing.T) {
+	lc := lifecycle.New()
+
+	calls := make([]string, 0)
+	hook := func(name string, dependsOn ...string) lifecycle.Hook {
+		return lifecycle.Hook{
+			Name:      name,
+			DependsOn: dependsOn,
+			OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+				calls = append(calls, "start "+name)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				calls = append(calls, "stop "+name)
+				return nil
+			},
+		}
+	}
+
+	lc.Append(hook("a", "b"))
+	lc.Append(hook("b"))
+	lc.Append(lifecycle.Hook{
+		Name: "c",
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			calls = append(calls, "start c")
+			ccf(nil)
+			return nil
+		},
+	})
+
+	var runErr *lifecycle.RunError
+	if err := lc.Run(context.Background()); !errors.As(err, &runErr) || len(runErr.Failures) != 0 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"start b", "start a", "start c", "stop a", "stop b"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestLifecycle_invalidDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		hooks []lifecycle.Hook
+	}{
+		{
+			name: "unknown",
+			hooks: []lifecycle.Hook{
+				{Name: "a", DependsOn: []string{"b"}},
+			},
+		},
+		{
+			name: "ambiguous",
+			hooks: []lifecycle.Hook{
+				{Name: "a"},
+				{Name: "a"},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+		},
+		{
+			name: "cycle",
+			hooks: []lifecycle.Hook{
+				{Name: "a", DependsOn: []string{"c"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := lifecycle.New()
+
+			started := false
+			for _, h := range tt.hooks {
+				h.OnStart = func(ctx context.Context, ccf context.CancelCauseFunc) error {
+					started = true
+					return nil
+				}
+				lc.Append(h)
+			}
+
+			err := lc.Run(context.Background())
+			if err == nil {
+				t.Error("expected an error")
+				return
+			}
+			t.Logf("got expected error: %s", err.Error())
+
+			if started {
+				t.Error("expected no hooks to be started")
+			}
+		})
+	}
+}
+
+func TestLifecycle_startTimeout(t *testing.T) {
+	lc := lifecycle.New()
+
+	stopped := false
+	lc.Append(lifecycle.Hook{
+		Name: "first",
+		OnStop: func(ctx context.Context) error {
+			stopped = true
+			return nil
+		},
+	})
+
+	hang := make(chan struct{})
+	defer close(hang)
+
+	lc.Append(lifecycle.Hook{
+		Name:         "hung",
+		StartTimeout: 20 * time.Millisecond,
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			<-hang
+			return nil
+		},
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), `"hung"`) {
+		t.Errorf("expected error to name the hook, got %q", err.Error())
+	}
+	if !stopped {
+		t.Error("expected first hook to be stopped")
+	}
+}
+
+func TestLifecycle_startTimeout_context(t *testing.T) {
+	lc := lifecycle.New()
+
+	var startCtx context.Context
+	lc.Append(lifecycle.Hook{
+		StartTimeout: 10 * time.Millisecond,
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			startCtx = ctx
+			return nil
+		},
+	})
+
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			time.Sleep(20 * time.Millisecond)
+			if err := startCtx.Err(); err != nil {
+				t.Errorf("expected the start context to live until the hook is stopped, got %v", err)
+			}
+			ccf(nil)
+			return nil
+		},
+	})
+
+	lc.Run(context.Background())
+
+	if startCtx.Err() == nil {
+		t.Error("expected the start context to be canceled once the hook is stopped")
+	}
+}
+
+func TestLifecycle_stopTimeout(t *testing.T) {
+	lc := lifecycle.New()
+
+	lc.Go(lifecycle.Actor{
+		Name:        "worker",
+		StopTimeout: 20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			return errors.New("failed")
+		},
+		Shutdown: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), `"worker"`) {
+		t.Errorf("expected error to name the actor, got %q", err.Error())
+	}
+}
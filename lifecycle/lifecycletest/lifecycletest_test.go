@@ -0,0 +1,126 @@
+package lifecycletest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+	"github.com/infastin/gorack/lifecycle/lifecycletest"
+)
+
+func TestRunner(t *testing.T) {
+	rec := lifecycletest.NewRecorder()
+
+	lc := lifecycle.New()
+	lc.Append(rec.Hook("a"))
+	lc.GoReady(rec.Actor("b"))
+	lc.Append(rec.Hook("c", lifecycletest.FailStop(errStop)))
+
+	r := lifecycletest.Run(t, lc)
+	r.WaitStarted(t)
+
+	rec.AssertCalls(t,
+		lifecycletest.Call{Name: "a", Stage: lifecycle.StageStart},
+		lifecycletest.Call{Name: "b", Stage: lifecycle.StageRun},
+		lifecycletest.Call{Name: "c", Stage: lifecycle.StageStart},
+	)
+
+	if err := r.Stop(t); !errors.Is(err, errStop) {
+		t.Errorf("expected the stop error, got %v", err)
+	}
+
+	rec.AssertCalls(t,
+		lifecycletest.Call{Name: "a", Stage: lifecycle.StageStart},
+		lifecycletest.Call{Name: "b", Stage: lifecycle.StageRun},
+		lifecycletest.Call{Name: "c", Stage: lifecycle.StageStart},
+		lifecycletest.Call{Name: "c", Stage: lifecycle.StageStop, Err: errStop},
+		lifecycletest.Call{Name: "b", Stage: lifecycle.StageStop},
+		lifecycletest.Call{Name: "a", Stage: lifecycle.StageStop},
+	)
+}
+
+func TestRunner_startFailure(t *testing.T) {
+	rec := lifecycletest.NewRecorder()
+
+	errStart := errors.New("failed to start")
+
+	lc := lifecycle.New()
+	lc.Append(rec.Hook("a"))
+	lc.Append(rec.Hook("b", lifecycletest.FailStart(errStart)))
+	lc.Append(rec.Hook("c"))
+
+	r := lifecycletest.Run(t, lc)
+	if err := r.Wait(t); !errors.Is(err, errStart) {
+		t.Errorf("expected the start error, got %v", err)
+	}
+
+	rec.AssertCalls(t,
+		lifecycletest.Call{Name: "a", Stage: lifecycle.StageStart},
+		lifecycletest.Call{Name: "b", Stage: lifecycle.StageStart, Err: errStart},
+		lifecycletest.Call{Name: "a", Stage: lifecycle.StageStop},
+	)
+}
+
+func TestRunner_hang(t *testing.T) {
+	rec := lifecycletest.NewRecorder()
+
+	lc := lifecycle.New(lifecycle.WithStopTimeout(10 * time.Millisecond))
+	lc.Append(rec.Hook("a"))
+	lc.Append(rec.Hook("b", lifecycletest.HangStop()))
+
+	r := lifecycletest.Run(t, lc)
+	r.WaitStarted(t)
+
+	// The stop timeout is shared, so the next hook fails to stop too.
+	var runErr *lifecycle.RunError
+	if err := r.Stop(t); !errors.As(err, &runErr) || len(runErr.Failures) != 2 {
+		t.Fatalf("expected two failures, got %v", err)
+	}
+	if failure := runErr.Failures[0]; failure.Name != "b" || failure.Stage != lifecycle.StageStop {
+		t.Errorf("unexpected failure: %v", failure)
+	}
+}
+
+var errStop = errors.New("failed to stop")
+
+func TestRunner_hangStart(t *testing.T) {
+	rec := lifecycletest.NewRecorder()
+
+	lc := lifecycle.New()
+	lc.Append(rec.Hook("a"))
+	lc.Append(rec.Hook("b", lifecycletest.HangStart(), lifecycletest.StartTimeout(10*time.Millisecond)))
+
+	r := lifecycletest.Run(t, lc)
+	if err := r.Wait(t); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the start timeout, got %v", err)
+	}
+
+	// OnStart of the hung hook returns concurrently with stopping,
+	// so its call is recorded at an arbitrary position.
+	deadline := time.Now().Add(time.Second)
+	for len(rec.Calls()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	calls := rec.Calls()
+	hung := slices.IndexFunc(calls, func(c lifecycletest.Call) bool {
+		return c.Name == "b"
+	})
+	if hung == -1 || !errors.Is(calls[hung].Err, context.Canceled) {
+		t.Fatalf("expected the hung hook to return once its context is canceled, got %v", calls)
+	}
+	calls = slices.Delete(calls, hung, hung+1)
+
+	expected := []lifecycletest.Call{
+		{Name: "a", Stage: lifecycle.StageStart},
+		{Name: "a", Stage: lifecycle.StageStop},
+	}
+	if !slices.EqualFunc(calls, expected, func(a, b lifecycletest.Call) bool {
+		return a.Name == b.Name && a.Stage == b.Stage && a.Err == nil
+	}) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
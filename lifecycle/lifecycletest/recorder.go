@@ -0,0 +1,221 @@
+package lifecycletest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+// Call is a call of a fake hook.
+type Call struct {
+	Name  string
+	Stage lifecycle.Stage
+	// Err is the error returned from the call.
+	Err error
+}
+
+func (c Call) String() string {
+	var b strings.Builder
+	b.WriteString(c.Stage.String())
+	b.WriteByte(' ')
+	b.WriteString(c.Name)
+	if c.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(c.Err.Error())
+	}
+	return b.String()
+}
+
+type fake struct {
+	dependsOn    []string
+	startTimeout time.Duration
+	startErr     error
+	stopErr      error
+	runErr       error
+	hangStart    bool
+	hangStop     bool
+}
+
+// FakeOption configures the behavior of a fake hook.
+type FakeOption func(f *fake)
+
+// DependsOn sets the dependencies of the fake hook.
+func DependsOn(names ...string) FakeOption {
+	return func(f *fake) {
+		f.dependsOn = names
+	}
+}
+
+// FailStart makes OnStart of the fake hook return the error.
+func FailStart(err error) FakeOption {
+	return func(f *fake) {
+		f.startErr = err
+	}
+}
+
+// StartTimeout sets the start timeout of the fake hook.
+func StartTimeout(timeout time.Duration) FakeOption {
+	return func(f *fake) {
+		f.startTimeout = timeout
+	}
+}
+
+// HangStart makes OnStart of the fake hook block until its context is done,
+// which only happens once the hook is stopped or fails to start.
+// Requires StartTimeout, since the hook is never stopped until it is started.
+func HangStart() FakeOption {
+	return func(f *fake) {
+		f.hangStart = true
+	}
+}
+
+// FailStop makes OnStop of the fake hook or Shutdown of the fake actor return the error.
+func FailStop(err error) FakeOption {
+	return func(f *fake) {
+		f.stopErr = err
+	}
+}
+
+// HangStop makes OnStop of the fake hook or Shutdown of the fake actor
+// block until its context is done.
+func HangStop() FakeOption {
+	return func(f *fake) {
+		f.hangStop = true
+	}
+}
+
+// FailRun makes Run of the fake actor return the error right away.
+func FailRun(err error) FakeOption {
+	return func(f *fake) {
+		f.runErr = err
+	}
+}
+
+// Recorder creates fake hooks and records their calls.
+// It is safe to use concurrently.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		mu:    sync.Mutex{},
+		calls: make([]Call, 0),
+	}
+}
+
+// Hook returns the fake hook, whose calls of OnStart and OnStop
+// are recorded once they return.
+func (r *Recorder) Hook(name string, opts ...FakeOption) lifecycle.Hook {
+	f := newFake(opts)
+
+	return lifecycle.Hook{
+		Name:         name,
+		DependsOn:    f.dependsOn,
+		StartTimeout: f.startTimeout,
+		OnStart: func(ctx context.Context, cancel context.CancelCauseFunc) error {
+			return r.record(name, lifecycle.StageStart, call(ctx, f.hangStart, f.startErr))
+		},
+		OnStop: func(ctx context.Context) error {
+			return r.record(name, lifecycle.StageStop, call(ctx, f.hangStop, f.stopErr))
+		},
+	}
+}
+
+// Actor returns the fake actor, whose Run is recorded once it is called.
+// Run then signals that the actor is ready and blocks until its context is done,
+// unless FailRun is given. Calls of Shutdown are recorded once they return.
+//
+// The actor is meant to be appended with GoReady,
+// so that Run is recorded before the hooks depending on it are started.
+func (r *Recorder) Actor(name string, opts ...FakeOption) lifecycle.ReadyActor {
+	f := newFake(opts)
+
+	return lifecycle.ReadyActor{
+		Name:      name,
+		DependsOn: f.dependsOn,
+		Run: func(ctx context.Context, ready func()) error {
+			r.record(name, lifecycle.StageRun, f.runErr)
+			if f.runErr != nil {
+				return f.runErr
+			}
+			ready()
+			<-ctx.Done()
+			return nil
+		},
+		Shutdown: func(ctx context.Context) error {
+			return r.record(name, lifecycle.StageStop, call(ctx, f.hangStop, f.stopErr))
+		},
+	}
+}
+
+// Calls returns the recorded calls in the order they were recorded.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// AssertCalls checks that the recorded calls are exactly the expected ones.
+// Errors of the calls are compared with errors.Is.
+func (r *Recorder) AssertCalls(t testing.TB, expected ...Call) {
+	t.Helper()
+
+	calls := r.Calls()
+	if len(calls) != len(expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+		return
+	}
+
+	for i := range calls {
+		got, want := calls[i], expected[i]
+		if got.Name != want.Name || got.Stage != want.Stage ||
+			(want.Err == nil) != (got.Err == nil) || !errors.Is(got.Err, want.Err) {
+			t.Errorf("expected calls %v, got %v", expected, calls)
+			return
+		}
+	}
+}
+
+func (r *Recorder) record(name string, stage lifecycle.Stage, err error) error {
+	r.mu.Lock()
+	r.calls = append(r.calls, Call{
+		Name:  name,
+		Stage: stage,
+		Err:   err,
+	})
+	r.mu.Unlock()
+	return err
+}
+
+func newFake(opts []FakeOption) *fake {
+	f := &fake{
+		dependsOn:    nil,
+		startTimeout: 0,
+		startErr:     nil,
+		stopErr:      nil,
+		runErr:       nil,
+		hangStart:    false,
+		hangStop:     false,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func call(ctx context.Context, hang bool, err error) error {
+	if hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return err
+}
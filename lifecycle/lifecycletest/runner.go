@@ -0,0 +1,163 @@
+// Package lifecycletest provides utilities for testing code,
+// which registers hooks in lifecycle.Lifecycle.
+package lifecycletest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+type config struct {
+	timeout time.Duration
+	ctx     context.Context
+}
+
+func defaultConfig() config {
+	return config{
+		timeout: 10 * time.Second,
+		ctx:     context.Background(),
+	}
+}
+
+type Option func(cfg *config)
+
+// WithTimeout sets the time Runner waits for the lifecycle
+// to start or to stop before failing the test.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithContext sets the context passed to Run of the lifecycle.
+func WithContext(ctx context.Context) Option {
+	return func(cfg *config) {
+		cfg.ctx = ctx
+	}
+}
+
+// Runner runs the lifecycle in the background.
+type Runner struct {
+	lc      *lifecycle.Lifecycle
+	timeout time.Duration
+	cancel  context.CancelFunc
+	done    chan struct{}
+	err     error
+}
+
+// Run starts running the lifecycle in the background.
+// The lifecycle is stopped once the test finishes, if it hasn't been stopped earlier.
+func Run(t testing.TB, lc *lifecycle.Lifecycle, opts ...Option) *Runner {
+	t.Helper()
+
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithCancel(cfg.ctx)
+
+	r := &Runner{
+		lc:      lc,
+		timeout: cfg.timeout,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		err:     nil,
+	}
+
+	go func() {
+		r.err = lc.Run(ctx)
+		close(r.done)
+	}()
+
+	t.Cleanup(func() {
+		r.cancel()
+
+		timer := time.NewTimer(r.timeout)
+		defer timer.Stop()
+
+		select {
+		case <-r.done:
+		case <-timer.C:
+			t.Errorf("lifecycle has not stopped within %s", r.timeout)
+		}
+	})
+
+	return r
+}
+
+// WaitStarted waits for all hooks to be started.
+// Fails the test if the lifecycle stops before all hooks have been started,
+// or if they haven't been started within the timeout.
+func (r *Runner) WaitStarted(t testing.TB) {
+	t.Helper()
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		switch r.lc.Status().Phase {
+		case lifecycle.PhaseRunning:
+			return
+		case lifecycle.PhaseStopping, lifecycle.PhaseStopped:
+			r.wait(t)
+			t.Fatalf("lifecycle stopped before all hooks have been started: %v", r.err)
+		}
+
+		select {
+		case <-r.done:
+			if r.err == nil {
+				// The lifecycle has no hooks.
+				return
+			}
+			t.Fatalf("lifecycle stopped before all hooks have been started: %v", r.err)
+		case <-timer.C:
+			t.Fatalf("hooks have not been started within %s", r.timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the lifecycle and waits for Run to return.
+// Fails the test if the lifecycle hasn't stopped within the timeout.
+//
+// Returns the error returned from Run.
+func (r *Runner) Stop(t testing.TB) error {
+	t.Helper()
+	r.cancel()
+	return r.Wait(t)
+}
+
+// Wait waits for Run to return without stopping the lifecycle.
+// Fails the test if the lifecycle hasn't stopped within the timeout.
+//
+// Returns the error returned from Run.
+func (r *Runner) Wait(t testing.TB) error {
+	t.Helper()
+	r.wait(t)
+	return r.err
+}
+
+// Done returns a channel that's closed once Run returns.
+func (r *Runner) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Runner) wait(t testing.TB) {
+	t.Helper()
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-r.done:
+	case <-timer.C:
+		t.Fatalf("lifecycle has not stopped within %s", r.timeout)
+	}
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stage is the stage of a hook the observer is notified about.
+type Stage int
+
+const (
+	// OnStart of a hook, or waiting for an actor to become ready.
+	StageStart Stage = iota
+	// OnStop of a hook, or Shutdown of an actor.
+	StageStop
+	// Run of an actor.
+	StageRun
+	// OnReload of a hook, or Reload of an actor.
+	StageReload
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StageStart:
+		return "start"
+	case StageStop:
+		return "stop"
+	case StageRun:
+		return "run"
+	case StageReload:
+		return "reload"
+	default:
+		panic(fmt.Sprintf("invalid stage: %d", s))
+	}
+}
+
+func (s Stage) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// HookEvent describes a stage of a hook.
+type HookEvent struct {
+	Name  string
+	Kind  Kind
+	Stage Stage
+	// Duration is the time the stage took.
+	// Zero for the events passed to HookBegin.
+	Duration time.Duration
+	// Err is the error the stage has ended with.
+	// Always nil for the events passed to HookBegin.
+	Err error
+}
+
+// RunEvent describes a completed Run of the lifecycle.
+type RunEvent struct {
+	Duration time.Duration
+	Err      error
+}
+
+// Observer is notified about the execution of hooks,
+// which allows to report their durations and outcomes as metrics or traces.
+//
+// Observer methods may be called concurrently and must not block.
+type Observer interface {
+	// HookBegin is called when a hook enters a stage.
+	HookBegin(event HookEvent)
+	// HookEnd is called when a hook completes a stage.
+	// For actors, completion of StageRun means that Run has exited.
+	HookEnd(event HookEvent)
+	// RunEnd is called when Run of the lifecycle returns.
+	RunEnd(event RunEvent)
+}
+
+type nopObserver struct{}
+
+func (nopObserver) HookBegin(HookEvent) {}
+func (nopObserver) HookEnd(HookEvent)   {}
+func (nopObserver) RunEnd(RunEvent)     {}
+
+// observe notifies the observer about the stage of the hook at index i
+// and returns the function to be called once the stage is completed,
+// which returns HookError if the stage has failed.
+func (l *Lifecycle) observe(i int, stage Stage) (end func(err error) *HookError) {
+	event := HookEvent{
+		Name:  l.hooks[i].name,
+		Kind:  l.hooks[i].kind,
+		Stage: stage,
+	}
+
+	l.observer.HookBegin(event)
+	start := time.Now()
+
+	return func(err error) *HookError {
+		event.Duration = time.Since(start)
+		event.Err = err
+		l.observer.HookEnd(event)
+
+		if err == nil {
+			return nil
+		}
+
+		return &HookError{
+			Name:     event.Name,
+			Index:    i,
+			Kind:     event.Kind,
+			Stage:    event.Stage,
+			Duration: event.Duration,
+			Err:      err,
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+	runErr error
+}
+
+func (o *recordingObserver) HookBegin(event lifecycle.HookEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, "begin "+event.Stage.String()+" "+event.Name)
+}
+
+func (o *recordingObserver) HookEnd(event lifecycle.HookEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	res := "ok"
+	if event.Err != nil {
+		res = "failed"
+	}
+	o.events = append(o.events, "end "+event.Stage.String()+" "+event.Name+" "+res)
+}
+
+func (o *recordingObserver) RunEnd(event lifecycle.RunEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, "run end")
+	o.runErr = event.Err
+}
+
+func TestLifecycle_observer(t *testing.T) {
+	observer := &recordingObserver{}
+	lc := lifecycle.New(lifecycle.WithObserver(observer))
+
+	errFailed := errors.New("failed")
+
+	lc.Append(lifecycle.Hook{
+		Name: "db",
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return nil
+		},
+	})
+
+	lc.Go(lifecycle.Actor{
+		Name: "worker",
+		Run: func(ctx context.Context) error {
+			return errFailed
+		},
+	})
+
+	if err := lc.Run(context.Background()); !errors.Is(err, errFailed) {
+		t.Errorf("expected the actor error, got %v", err)
+	}
+
+	expected := []string{
+		"begin start db",
+		"end start db ok",
+		"begin start worker",
+		"begin run worker",
+		"end start worker ok",
+		"end run worker failed",
+		"begin stop db",
+		"end stop db ok",
+		"run end",
+	}
+
+	// Actor runs concurrently, so the order of its events relative to the others is not fixed.
+	got := observer.events
+	if len(got) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, got)
+	}
+	for _, event := range expected {
+		if !slices.Contains(got, event) {
+			t.Errorf("expected event %q, got %v", event, got)
+		}
+	}
+	if slices.Index(got, "begin run worker") > slices.Index(got, "end run worker failed") {
+		t.Errorf("expected run of the actor to begin before it ends, got %v", got)
+	}
+	if got[len(got)-1] != "run end" || !errors.Is(observer.runErr, errFailed) {
+		t.Errorf("expected run end to be reported last with the actor error, got %v", got)
+	}
+}
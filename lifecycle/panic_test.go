@@ -0,0 +1,79 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestLifecycle_panic(t *testing.T) {
+	tests := []struct {
+		name   string
+		append func(lc *lifecycle.Lifecycle)
+	}{
+		{
+			name: "OnStart",
+			append: func(lc *lifecycle.Lifecycle) {
+				lc.Append(lifecycle.Hook{
+					OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+						panic("boom")
+					},
+				})
+			},
+		},
+		{
+			name: "OnStop",
+			append: func(lc *lifecycle.Lifecycle) {
+				lc.Append(lifecycle.Hook{
+					OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+						ccf(nil)
+						return nil
+					},
+					OnStop: func(ctx context.Context) error {
+						panic("boom")
+					},
+				})
+			},
+		},
+		{
+			name: "Run",
+			append: func(lc *lifecycle.Lifecycle) {
+				lc.GoFunc(func(ctx context.Context) error {
+					panic("boom")
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := lifecycle.New()
+
+			stopped := false
+			lc.Append(lifecycle.Hook{
+				OnStop: func(ctx context.Context) error {
+					stopped = true
+					return nil
+				},
+			})
+
+			tt.append(lc)
+
+			err := lc.Run(context.Background())
+
+			var panicErr *lifecycle.PanicError
+			if !errors.As(err, &panicErr) {
+				t.Errorf("expected PanicError, got %v", err)
+				return
+			}
+			if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+				t.Errorf("expected panic value and stack trace, got %v", panicErr)
+			}
+			if !stopped {
+				t.Error("expected the other hook to be stopped")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestGoReady(t *testing.T) {
+	lc := lifecycle.New()
+
+	var isReady atomic.Bool
+	lc.GoReady(lifecycle.ReadyActor{
+		Name: "listener",
+		Run: func(ctx context.Context, ready func()) error {
+			time.Sleep(20 * time.Millisecond)
+			isReady.Store(true)
+			ready()
+			<-ctx.Done()
+			return nil
+		},
+	})
+
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			if !isReady.Load() {
+				t.Error("expected the actor to be ready")
+			}
+			ccf(nil)
+			return nil
+		},
+	})
+
+	if err := lc.Run(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestGoReady_earlyError(t *testing.T) {
+	lc := lifecycle.New()
+
+	errFailed := errors.New("failed to bind")
+	lc.GoReady(lifecycle.ReadyActor{
+		Run: func(ctx context.Context, ready func()) error {
+			return errFailed
+		},
+	})
+
+	started := false
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			started = true
+			return nil
+		},
+	})
+
+	if err := lc.Run(context.Background()); !errors.Is(err, errFailed) {
+		t.Errorf("expected the actor error, got %v", err)
+	}
+	if started {
+		t.Error("expected the hook to not be started")
+	}
+}
+
+func TestGoReady_startTimeout(t *testing.T) {
+	lc := lifecycle.New()
+
+	exited := make(chan struct{})
+	lc.GoReady(lifecycle.ReadyActor{
+		Name:         "slow",
+		StartTimeout: 20 * time.Millisecond,
+		Run: func(ctx context.Context, ready func()) error {
+			<-ctx.Done()
+			close(exited)
+			return nil
+		},
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), `"slow"`) {
+		t.Errorf("expected error to name the actor, got %q", err.Error())
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Error("expected the actor to exit")
+	}
+
+	if state := lc.Status().Hooks[0].State; state != lifecycle.StateFailed {
+		t.Errorf("expected the actor to stay failed, got %s", state)
+	}
+}
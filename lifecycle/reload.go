@@ -0,0 +1,109 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var ErrNotRunning = errors.New("lifecycle: not running")
+
+func reloadFunc(logger *slog.Logger, fn func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		logger.Info("running reload hook")
+		err := protect(func() error {
+			return fn(ctx)
+		})
+		if err != nil {
+			logger.Error("reload hook ran with failure", slog.String("error", err.Error()))
+		} else {
+			logger.Info("reload hook ran successfully")
+		}
+		return err
+	}
+}
+
+// Reload calls reload functions of the running hooks in the order they were started.
+// Nothing is stopped, and reload errors don't stop the lifecycle.
+//
+// Returns ErrNotRunning if all hooks have not been started yet or are being stopped.
+// Otherwise, returns the errors returned from reload functions.
+func (l *Lifecycle) Reload(ctx context.Context) error {
+	l.reloadMu.Lock()
+	defer l.reloadMu.Unlock()
+
+	l.mu.Lock()
+	phase, deps := l.phase, l.deps
+	l.mu.Unlock()
+
+	if phase != PhaseRunning {
+		return ErrNotRunning
+	}
+
+	var mu sync.Mutex
+	errs := make([]error, 0)
+
+	walk(deps, func(i int) bool {
+		hook := &l.hooks[i]
+		if hook.onReload == nil {
+			return true
+		}
+
+		l.mu.Lock()
+		running := hook.state == StateRunning
+		l.mu.Unlock()
+		if !running {
+			return true
+		}
+
+		end := l.observe(i, StageReload)
+		if err := end(hook.onReload(ctx)); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+
+		return true
+	})
+
+	return errors.Join(errs...)
+}
+
+// ReloadSignal returns the hook, which reloads the lifecycle
+// every time one of the signals is received.
+// If no signals are given, SIGHUP is used.
+// Reload errors are logged and don't stop the lifecycle.
+func (l *Lifecycle) ReloadSignal(signals ...os.Signal) Hook {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	return Hook{
+		Name: "reload signal handler",
+		OnStart: func(ctx context.Context, cancel context.CancelCauseFunc) error {
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, signals...)
+
+			go func() {
+				defer signal.Stop(sigCh)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case sig := <-sigCh:
+						l.logger.Info("reloading", slog.String("signal", sig.String()))
+						if err := l.Reload(ctx); err != nil {
+							l.logger.Error("reload failed", slog.String("error", err.Error()))
+						}
+					}
+				}
+			}()
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestLifecycle_Reload(t *testing.T) {
+	lc := lifecycle.New()
+
+	if err := lc.Reload(context.Background()); !errors.Is(err, lifecycle.ErrNotRunning) {
+		t.Errorf("expected lifecycle.ErrNotRunning, got %v", err)
+	}
+
+	var mu sync.Mutex
+	reloaded := make([]string, 0)
+	stopped := false
+
+	errFailed := errors.New("failed")
+	for _, name := range []string{"a", "b", "c"} {
+		lc.Append(lifecycle.Hook{
+			Name: name,
+			OnReload: func(ctx context.Context) error {
+				mu.Lock()
+				reloaded = append(reloaded, name)
+				mu.Unlock()
+				if name == "b" {
+					return errFailed
+				}
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				stopped = true
+				return nil
+			},
+		})
+	}
+
+	ready := make(chan struct{})
+	lc.Append(lifecycle.Hook{
+		OnStart: func(ctx context.Context, ccf context.CancelCauseFunc) error {
+			close(ready)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- lc.Run(ctx)
+	}()
+
+	<-ready
+	for lc.Status().Phase != lifecycle.PhaseRunning {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := lc.Reload(context.Background()); !errors.Is(err, errFailed) {
+		t.Errorf("expected the reload error, got %v", err)
+	}
+	if expected := []string{"a", "b", "c"}; !slices.Equal(expected, reloaded) {
+		t.Errorf("slices must be equal: expected=%v got=%v", expected, reloaded)
+	}
+	if stopped {
+		t.Error("expected hooks to not be stopped on reload")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestLifecycle_ReloadSignal(t *testing.T) {
+	lc := lifecycle.New()
+
+	reloaded := make(chan struct{})
+	lc.Append(lc.ReloadSignal())
+	lc.Append(lifecycle.Hook{
+		OnReload: func(ctx context.Context) error {
+			close(reloaded)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- lc.Run(ctx)
+	}()
+
+	for lc.Status().Phase != lifecycle.PhaseRunning {
+		time.Sleep(time.Millisecond)
+	}
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("failed to find process: %s", err.Error())
+	}
+	if err := p.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("failed to send signal: %s", err.Error())
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Error("expected the lifecycle to be reloaded")
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,88 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// RestartPolicy describes how an actor is restarted after a failure.
+//
+// The delay before a restart starts with MinBackoff and doubles with every restart
+// within Window, but doesn't exceed MaxBackoff.
+// Once the actor fails more than MaxRestarts times within Window,
+// its error is escalated and stops the lifecycle.
+type RestartPolicy struct {
+	// MaxRestarts is the maximum number of restarts within Window.
+	MaxRestarts int
+	// Window is the period of time restarts are counted in.
+	// Zero Window means all restarts are counted.
+	Window time.Duration
+	// MinBackoff is the delay before the first restart.
+	MinBackoff time.Duration
+	// MaxBackoff, if positive, is the maximum delay before a restart.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the delay that is randomly added to it,
+	// so that actors failing at the same time don't restart at the same time.
+	Jitter float64
+}
+
+func (p *RestartPolicy) backoff(restarts int) time.Duration {
+	delay := p.MinBackoff
+	for range restarts {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay += time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// supervise calls run until it succeeds, ctx is done
+// or the restart policy is exhausted, and returns the last error.
+func supervise(ctx context.Context, logger *slog.Logger, policy *RestartPolicy, run func(context.Context) error) error {
+	restarts := make([]time.Time, 0)
+	for {
+		err := run(ctx)
+		if err == nil || policy == nil || ctx.Err() != nil {
+			return err
+		}
+
+		now := time.Now()
+		if policy.Window > 0 {
+			k := 0
+			for k < len(restarts) && now.Sub(restarts[k]) > policy.Window {
+				k++
+			}
+			restarts = restarts[k:]
+		}
+
+		if len(restarts) >= policy.MaxRestarts {
+			return fmt.Errorf("restarted too many times: %w", err)
+		}
+
+		delay := policy.backoff(len(restarts))
+		restarts = append(restarts, now)
+
+		logger.Warn("restarting start hook",
+			slog.Int("restarts", len(restarts)),
+			slog.Duration("delay", delay),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
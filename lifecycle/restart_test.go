@@ -0,0 +1,68 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/lifecycle"
+)
+
+func TestActor_Restart(t *testing.T) {
+	lc := lifecycle.New()
+
+	errFailed := errors.New("failed")
+
+	var runs atomic.Int32
+	lc.Go(lifecycle.Actor{
+		Restart: &lifecycle.RestartPolicy{
+			MaxRestarts: 3,
+			Window:      time.Minute,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  4 * time.Millisecond,
+			Jitter:      0.5,
+		},
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return errFailed
+		},
+	})
+
+	if err := lc.Run(context.Background()); !errors.Is(err, errFailed) {
+		t.Errorf("expected the actor error to be escalated, got %v", err)
+	}
+	if n := runs.Load(); n != 4 {
+		t.Errorf("expected actor to run 4 times, ran %d times", n)
+	}
+}
+
+func TestActor_Restart_recover(t *testing.T) {
+	lc := lifecycle.New()
+
+	var runs atomic.Int32
+	lc.Go(lifecycle.Actor{
+		Restart: &lifecycle.RestartPolicy{
+			MaxRestarts: 1,
+			Window:      time.Minute,
+		},
+		Run: func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				return errors.New("failed")
+			}
+			<-ctx.Done()
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := lc.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if n := runs.Load(); n != 2 {
+		t.Errorf("expected actor to run 2 times, ran %d times", n)
+	}
+}
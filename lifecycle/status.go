@@ -0,0 +1,200 @@
+package lifecycle
+
+import "fmt"
+
+// Kind is the kind of a hook.
+type Kind int
+
+const (
+	// Hook appended with Append.
+	KindHook Kind = iota
+	// Actor appended with Go, GoFunc or GoReady.
+	KindActor
+	// Nested lifecycle appended with Group or AppendLifecycle.
+	KindGroup
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindHook:
+		return "hook"
+	case KindActor:
+		return "actor"
+	case KindGroup:
+		return "group"
+	default:
+		panic(fmt.Sprintf("invalid kind: %d", k))
+	}
+}
+
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Phase is the phase of the lifecycle.
+type Phase int
+
+const (
+	// Lifecycle is not running yet.
+	PhaseIdle Phase = iota
+	// Hooks are being started.
+	PhaseStarting
+	// All hooks have been started.
+	PhaseRunning
+	// Hooks are being stopped.
+	PhaseStopping
+	// All hooks have been stopped.
+	PhaseStopped
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseIdle:
+		return "idle"
+	case PhaseStarting:
+		return "starting"
+	case PhaseRunning:
+		return "running"
+	case PhaseStopping:
+		return "stopping"
+	case PhaseStopped:
+		return "stopped"
+	default:
+		panic(fmt.Sprintf("invalid phase: %d", p))
+	}
+}
+
+func (p Phase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// State is the state of a hook.
+type State int
+
+const (
+	// Hook is not started yet.
+	StatePending State = iota
+	// Hook is being started.
+	StateStarting
+	// Hook has been started, or actor is running.
+	StateRunning
+	// Actor has failed and is going to be restarted.
+	StateRestarting
+	// Hook is being stopped.
+	StateStopping
+	// Hook has been stopped, or actor has exited.
+	StateStopped
+	// Hook has failed to start or stop, or actor has exited with an error.
+	StateFailed
+)
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateRestarting:
+		return "restarting"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	case StateFailed:
+		return "failed"
+	default:
+		panic(fmt.Sprintf("invalid state: %d", s))
+	}
+}
+
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// HookStatus is a snapshot of the state of a hook.
+type HookStatus struct {
+	Name  string
+	Kind  Kind
+	State State
+	// Err is the error the hook has failed with.
+	Err error
+}
+
+// Status is a snapshot of the state of the lifecycle.
+type Status struct {
+	Phase Phase
+	Hooks []HookStatus
+}
+
+// Live reports whether the lifecycle is neither stopped nor has failed hooks.
+func (s *Status) Live() bool {
+	if s.Phase == PhaseStopped {
+		return false
+	}
+	for i := range s.Hooks {
+		if s.Hooks[i].State == StateFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Ready reports whether all hooks have been started and are running.
+func (s *Status) Ready() bool {
+	if s.Phase != PhaseRunning {
+		return false
+	}
+	for i := range s.Hooks {
+		if s.Hooks[i].State != StateRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// Status returns a snapshot of the state of the lifecycle.
+// It is safe to call Status concurrently with Run.
+func (l *Lifecycle) Status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hooks := make([]HookStatus, len(l.hooks))
+	for i := range l.hooks {
+		hook := &l.hooks[i]
+		hooks[i] = HookStatus{
+			Name:  hook.name,
+			Kind:  hook.kind,
+			State: hook.state,
+			Err:   hook.err,
+		}
+	}
+
+	return Status{
+		Phase: l.phase,
+		Hooks: hooks,
+	}
+}
+
+func (l *Lifecycle) getPhase() Phase {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.phase
+}
+
+func (l *Lifecycle) setPhase(phase Phase) {
+	l.mu.Lock()
+	l.phase = phase
+	l.mu.Unlock()
+}
+
+func (l *Lifecycle) setState(i int, state State, err error) {
+	l.mu.Lock()
+	hook := &l.hooks[i]
+	hook.state = state
+	if err != nil {
+		hook.err = err
+	}
+	l.mu.Unlock()
+}
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrRunning = errors.New("already running")
 	ErrClosed  = errors.New("closed")
+	// ErrNoFutures is returned from AwaitAny when no futures are given.
+	ErrNoFutures = errors.New("no futures")
 )
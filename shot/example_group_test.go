@@ -0,0 +1,29 @@
+package shot_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/infastin/gorack/shot"
+)
+
+func ExampleGroup() {
+	g := shot.NewGroup(context.Background())
+
+	worker := g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	g.Go(func(ctx context.Context) error {
+		return errors.New("failed")
+	})
+
+	// The first error cancels the context of the group, so the worker exits.
+	fmt.Println(g.Wait())
+	fmt.Println(worker.State())
+
+	// Output:
+	// failed
+	// closed
+}
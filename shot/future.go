@@ -0,0 +1,183 @@
+package shot
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+type result[T any] struct {
+	value T
+	err   error
+}
+
+// Future holds the value and the error returned from the goroutine.
+type Future[T any] struct {
+	s   One
+	res atomic.Pointer[result[T]]
+}
+
+// GoValue starts a goroutine and creates One, whose Context
+// is passed to the goroutine to control its state, similarly to GoCtx.
+//
+// If the goroutine panics, PanicError is stored as its error.
+//
+// Returns Future, which can be used to control the goroutine
+// and get the value and the error returned from the goroutine.
+func GoValue[T any](ctx context.Context, f func(ctx context.Context) (T, error)) *Future[T] {
+	fut := &Future[T]{s: NewOne(ctx), res: atomic.Pointer[result[T]]{}}
+	go func() {
+		stop, err := fut.s.Start()
+		if err != nil {
+			return
+		}
+		var value T
+		err = protect(func() (err error) {
+			value, err = f(fut.s.Context())
+			return err
+		})
+		fut.res.Store(&result[T]{value: value, err: err})
+		stop()
+	}()
+	return fut
+}
+
+// Await waits for the goroutine to exit and returns the value and the error returned from it.
+// If the future has been closed before the goroutine was started, returns ErrClosed.
+//
+// If ctx is done before the goroutine exits, returns the error of ctx.
+// Canceling ctx doesn't affect the goroutine in any way.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case <-f.s.Done():
+	}
+	value, _, err := f.TryGet()
+	return value, err
+}
+
+// TryGet returns true, and the value and the error returned from the goroutine,
+// if the goroutine has exited. Otherwise, returns false.
+// If the future has been closed before the goroutine was started, returns ErrClosed.
+func (f *Future[T]) TryGet() (value T, ok bool, err error) {
+	select {
+	case <-f.s.Done():
+	default:
+		return value, false, nil
+	}
+	res := f.res.Load()
+	if res == nil {
+		return value, true, ErrClosed
+	}
+	return res.value, true, res.err
+}
+
+// Close closes One passed to the goroutine.
+func (f *Future[T]) Close(ctx context.Context) error {
+	return f.s.Close(ctx)
+}
+
+// Done returns channel that is closed when the goroutine has exited.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.s.Done()
+}
+
+// State returns current state of One passed to the goroutine.
+func (f *Future[T]) State() State {
+	return f.s.State()
+}
+
+// AwaitAll waits for all futures to complete and returns their values in the same order.
+// Returns the error of the first future that fails, without waiting for the other futures,
+// or the error of ctx if it is done before all futures complete.
+func AwaitAll[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	values := make([]T, len(futures))
+	var firstErr error
+
+	err := awaitEach(ctx, futures, func(i int) bool {
+		value, _, err := futures[i].TryGet()
+		if err != nil {
+			firstErr = err
+			return false
+		}
+		values[i] = value
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return values, nil
+}
+
+// AwaitAny waits for the first future to complete successfully
+// and returns its index and value.
+// If all futures fail, returns -1 and the errors of all futures joined in the order they failed.
+// If ctx is done first, returns -1 and the error of ctx.
+// If no futures are given, returns -1 and ErrNoFutures.
+func AwaitAny[T any](ctx context.Context, futures ...*Future[T]) (int, T, error) {
+	idx := -1
+	var value T
+
+	if len(futures) == 0 {
+		return idx, value, ErrNoFutures
+	}
+	errs := make([]error, 0, len(futures))
+
+	err := awaitEach(ctx, futures, func(i int) bool {
+		v, _, err := futures[i].TryGet()
+		if err != nil {
+			errs = append(errs, err)
+			return true
+		}
+		idx, value = i, v
+		return false
+	})
+	if err != nil {
+		return -1, value, err
+	}
+	if idx == -1 {
+		return -1, value, errors.Join(errs...)
+	}
+
+	return idx, value, nil
+}
+
+// awaitEach calls fn with the index of every future in the order they complete,
+// until fn returns false or ctx is done.
+func awaitEach[T any](ctx context.Context, futures []*Future[T], fn func(i int) bool) error {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	completed := make(chan int)
+	for i, f := range futures {
+		go func() {
+			select {
+			case <-f.Done():
+				select {
+				case completed <- i:
+				case <-stop:
+				}
+			case <-stop:
+			}
+		}()
+	}
+
+	for range futures {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case i := <-completed:
+			if !fn(i) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package shot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/shot"
+)
+
+func TestFuture_Await(t *testing.T) {
+	release := make(chan struct{})
+	f := shot.GoValue(context.Background(), func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	if _, ok, _ := f.TryGet(); ok {
+		t.Error("TryGet(): expected the future to not be done")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := f.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Await(): expected=%v got=%v", context.DeadlineExceeded, err)
+	}
+
+	close(release)
+
+	value, err := f.Await(context.Background())
+	if err != nil || value != 42 {
+		t.Errorf("Await(): expected=42 got=%d, %v", value, err)
+	}
+	if value, ok, err := f.TryGet(); !ok || err != nil || value != 42 {
+		t.Errorf("TryGet(): expected=42 got=%d, %t, %v", value, ok, err)
+	}
+
+	shouldBe(t, f, shot.StateClosed)
+}
+
+func TestFuture_Close(t *testing.T) {
+	f := shot.GoValue(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	if err := f.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+	shouldBe(t, f, shot.StateClosed)
+
+	// The goroutine may have been closed before it was started.
+	if _, err := f.Await(context.Background()); !errors.Is(err, context.Canceled) && !errors.Is(err, shot.ErrClosed) {
+		t.Errorf("Await(): expected the future to be canceled, got %v", err)
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	futures := make([]*shot.Future[int], 3)
+	for i := range futures {
+		futures[i] = shot.GoValue(context.Background(), func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(len(futures)-i) * time.Millisecond)
+			return i, nil
+		})
+	}
+
+	values, err := shot.AwaitAll(context.Background(), futures...)
+	if err != nil {
+		t.Fatalf("AwaitAll(): unexpected error: %v", err)
+	}
+	for i, value := range values {
+		if value != i {
+			t.Errorf("AwaitAll(): expected values in order, got %v", values)
+			break
+		}
+	}
+
+	errFailed := errors.New("failed")
+	failing := shot.GoValue(context.Background(), func(ctx context.Context) (int, error) {
+		return 0, errFailed
+	})
+	hanging := shot.GoValue(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, nil
+	})
+	defer hanging.Close(context.Background())
+
+	if _, err := shot.AwaitAll(context.Background(), hanging, failing); !errors.Is(err, errFailed) {
+		t.Errorf("AwaitAll(): expected=%v got=%v", errFailed, err)
+	}
+}
+
+func TestAwaitAny(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	failing := shot.GoValue(context.Background(), func(ctx context.Context) (string, error) {
+		return "", errFailed
+	})
+	succeeding := shot.GoValue(context.Background(), func(ctx context.Context) (string, error) {
+		<-failing.Done()
+		return "ok", nil
+	})
+	hanging := shot.GoValue(context.Background(), func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", nil
+	})
+	defer hanging.Close(context.Background())
+
+	i, value, err := shot.AwaitAny(context.Background(), failing, hanging, succeeding)
+	if err != nil || i != 2 || value != "ok" {
+		t.Errorf("AwaitAny(): expected=2, ok got=%d, %s, %v", i, value, err)
+	}
+
+	i, _, err = shot.AwaitAny(context.Background(), failing, failing)
+	if i != -1 || !errors.Is(err, errFailed) {
+		t.Errorf("AwaitAny(): expected=-1, %v got=%d, %v", errFailed, i, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if i, _, err := shot.AwaitAny(ctx, hanging); i != -1 || !errors.Is(err, context.Canceled) {
+		t.Errorf("AwaitAny(): expected=-1, %v got=%d, %v", context.Canceled, i, err)
+	}
+}
+
+func TestAwaitAny_empty(t *testing.T) {
+	i, _, err := shot.AwaitAny[int](context.Background())
+	if i != -1 || !errors.Is(err, shot.ErrNoFutures) {
+		t.Errorf("AwaitAny(): expected=-1, %v got=%d, %v", shot.ErrNoFutures, i, err)
+	}
+}
@@ -0,0 +1,160 @@
+package shot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Group is a collection of goroutines, called members,
+// which share the context of the group.
+// The first error returned from a member cancels the context of the group,
+// so that all other members exit.
+type Group struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     atomic.Value
+}
+
+// NewGroup creates Group with the given parent context.
+func NewGroup(parent context.Context) *Group {
+	ctx, cancel := context.WithCancelCause(parent)
+	return &Group{
+		ctx:     ctx,
+		cancel:  cancel,
+		sem:     nil,
+		wg:      sync.WaitGroup{},
+		errOnce: sync.Once{},
+		err:     atomic.Value{},
+	}
+}
+
+// SetLimit limits the number of members running at once to n.
+// A negative value indicates no limit.
+//
+// Must not be called after Go.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go starts a member, whose Context is passed to f, similarly to GoCtx.
+//
+// If the number of running members has reached the limit,
+// the member stays in the Created state until another member exits.
+// If the group or the member is closed before the member is started,
+// f is not called at all, and the error of the member is ErrClosed.
+// If f panics, PanicError is the error of the member.
+//
+// Returns G, which can be used to control the member
+// and get the error returned from the member.
+func (g *Group) Go(f func(ctx context.Context) error) *G {
+	m := &G{s: NewOne(g.ctx), err: atomic.Value{}}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if !g.acquire(m.s.Context()) {
+			m.err.Store(ErrClosed)
+			m.s.Close(context.Background())
+			return
+		}
+		defer g.release()
+
+		stop, err := m.s.Start()
+		if err != nil {
+			m.err.Store(err)
+			return
+		}
+		err = protect(func() error {
+			return f(m.s.Context())
+		})
+		if err != nil {
+			m.err.Store(err)
+			g.fail(err)
+		}
+		stop()
+	}()
+	return m
+}
+
+func (g *Group) acquire(ctx context.Context) bool {
+	if g.sem == nil {
+		return ctx.Err() == nil
+	}
+	select {
+	case g.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (g *Group) release() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
+
+func (g *Group) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err.Store(err)
+		g.cancel(err)
+	})
+}
+
+// Wait waits for all members to exit, cancels the context of the group
+// and returns the first error returned from a member.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+	return g.Err()
+}
+
+// Close cancels the context of the group, which makes all members exit,
+// and waits for all members to exit.
+//
+// Context passed to this method can be canceled to pass control back to the caller
+// if members take too much time to exit.
+// Canceling the context passed to this method doesn't affect the members in any way.
+func (g *Group) Close(ctx context.Context) error {
+	g.cancel(nil)
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+	}
+
+	return nil
+}
+
+// Context returns the context of the group.
+//
+// Context is cancelled when a member returns an error,
+// the group is closed by Close method, or when the parent context is cancelled.
+func (g *Group) Context() context.Context {
+	return g.ctx
+}
+
+// Err returns the first error returned from a member.
+// If no member has returned an error yet, returns nil.
+func (g *Group) Err() error {
+	err, ok := g.err.Load().(error)
+	if !ok {
+		return nil
+	}
+	return err
+}
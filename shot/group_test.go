@@ -0,0 +1,149 @@
+package shot_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/shot"
+)
+
+func TestGroup_firstError(t *testing.T) {
+	g := shot.NewGroup(context.Background())
+
+	errFailed := errors.New("failed")
+
+	started := make(chan struct{})
+	waiting := g.Go(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+	<-started
+
+	failing := g.Go(func(ctx context.Context) error {
+		return errFailed
+	})
+
+	if err := g.Wait(); !errors.Is(err, errFailed) {
+		t.Fatalf("Wait(): expected=%v got=%v", errFailed, err)
+	}
+	if cause := context.Cause(g.Context()); !errors.Is(cause, errFailed) {
+		t.Errorf("Context(): expected cause=%v got=%v", errFailed, cause)
+	}
+
+	shouldBe(t, waiting, shot.StateClosed)
+	shouldBe(t, failing, shot.StateClosed)
+
+	if err := waiting.Err(); err != nil {
+		t.Errorf("Err(): expected=nil got=%v", err)
+	}
+	if err := failing.Err(); !errors.Is(err, errFailed) {
+		t.Errorf("Err(): expected=%v got=%v", errFailed, err)
+	}
+}
+
+func TestGroup_Close(t *testing.T) {
+	g := shot.NewGroup(context.Background())
+
+	members := make([]*shot.G, 3)
+	for i := range members {
+		members[i] = g.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+	}
+
+	if err := g.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+	for _, m := range members {
+		shouldBe(t, m, shot.StateClosed)
+	}
+	if err := g.Err(); err != nil {
+		t.Errorf("Err(): expected=nil got=%v", err)
+	}
+
+	m := g.Go(func(ctx context.Context) error {
+		t.Error("expected the member to not be started")
+		return nil
+	})
+	<-m.Done()
+	if err := m.Err(); !errors.Is(err, shot.ErrClosed) {
+		t.Errorf("Err(): expected=%v got=%v", shot.ErrClosed, err)
+	}
+}
+
+func TestGroup_Close_timeout(t *testing.T) {
+	g := shot.NewGroup(context.Background())
+
+	started := make(chan struct{})
+	exit := make(chan struct{})
+	g.Go(func(ctx context.Context) error {
+		close(started)
+		<-exit
+		return nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := g.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close(): expected=%v got=%v", context.DeadlineExceeded, err)
+	}
+
+	close(exit)
+	if err := g.Close(context.Background()); err != nil {
+		t.Errorf("Close(): unexpected error: %v", err)
+	}
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	g := shot.NewGroup(context.Background())
+	g.SetLimit(2)
+
+	var running, maxRunning atomic.Int32
+	release := make(chan struct{})
+
+	members := make([]*shot.G, 5)
+	for i := range members {
+		members[i] = g.Go(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				cur := maxRunning.Load()
+				if n <= cur || maxRunning.CompareAndSwap(cur, n) {
+					break
+				}
+			}
+			<-release
+			return nil
+		})
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	created := 0
+	for _, m := range members {
+		if m.State() == shot.StateCreated {
+			created++
+		}
+	}
+	if created != 3 {
+		t.Errorf("expected 3 members to wait for a slot, got %d", created)
+	}
+
+	close(release)
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait(): unexpected error: %v", err)
+	}
+	if n := maxRunning.Load(); n != 2 {
+		t.Errorf("expected at most 2 members to run at once, got %d", n)
+	}
+	for _, m := range members {
+		shouldBe(t, m, shot.StateClosed)
+	}
+}
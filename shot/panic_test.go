@@ -0,0 +1,74 @@
+package shot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/shot"
+)
+
+func shouldPanic(t *testing.T, err error, value any) {
+	t.Helper()
+
+	var panicErr *shot.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Err(): expected PanicError, got %v", err)
+	}
+	if panicErr.Value != value {
+		t.Errorf("Err(): expected panic value=%v got=%v", value, panicErr.Value)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("Err(): expected stack trace")
+	}
+}
+
+func TestGoErr_panic(t *testing.T) {
+	e := shot.GoErr(func() error {
+		panic("boom")
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for e.Err() == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	shouldPanic(t, e.Err(), "boom")
+}
+
+func TestGo_panic(t *testing.T) {
+	started := make(chan struct{})
+	g := shot.Go(context.Background(), func(state *shot.One) error {
+		if _, err := state.Start(); err != nil {
+			return err
+		}
+		close(started)
+		panic("boom")
+	})
+	<-started
+
+	if err := g.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+
+	shouldBe(t, g, shot.StateClosed)
+	shouldPanic(t, g.Err(), "boom")
+}
+
+func TestGoCtx_panic(t *testing.T) {
+	g := shot.GoCtx(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	<-g.Done()
+	if err := g.Close(ctx); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+
+	shouldBe(t, g, shot.StateClosed)
+	shouldPanic(t, g.Err(), "boom")
+}
@@ -0,0 +1,288 @@
+package shot
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Overrun defines what Periodic does with the runs
+// that should have started while the previous run was still in progress.
+type Overrun int
+
+const (
+	// Missed runs are skipped, and the next run starts on schedule.
+	OverrunSkip Overrun = iota
+	// A single missed run starts right after the previous run finishes,
+	// the rest of the missed runs are skipped.
+	OverrunQueue
+)
+
+func (o Overrun) String() string {
+	switch o {
+	case OverrunSkip:
+		return "skip"
+	case OverrunQueue:
+		return "queue"
+	default:
+		panic(fmt.Sprintf("invalid overrun: %d", o))
+	}
+}
+
+type periodicConfig struct {
+	jitter     time.Duration
+	immediate  bool
+	runTimeout time.Duration
+	overrun    Overrun
+}
+
+func defaultPeriodicConfig() periodicConfig {
+	return periodicConfig{
+		jitter:     0,
+		immediate:  false,
+		runTimeout: 0,
+		overrun:    OverrunSkip,
+	}
+}
+
+type PeriodicOption func(cfg *periodicConfig)
+
+// WithJitter delays every scheduled run by a random duration in [0, jitter).
+func WithJitter(jitter time.Duration) PeriodicOption {
+	return func(cfg *periodicConfig) {
+		cfg.jitter = jitter
+	}
+}
+
+// WithImmediate makes Periodic run the function right after it is started,
+// instead of waiting for the interval to pass.
+func WithImmediate() PeriodicOption {
+	return func(cfg *periodicConfig) {
+		cfg.immediate = true
+	}
+}
+
+// WithRunTimeout limits the time every run can take.
+func WithRunTimeout(timeout time.Duration) PeriodicOption {
+	return func(cfg *periodicConfig) {
+		cfg.runTimeout = timeout
+	}
+}
+
+// WithOverrun sets what to do with the runs missed because of a long run.
+// By default, OverrunSkip is used.
+func WithOverrun(overrun Overrun) PeriodicOption {
+	return func(cfg *periodicConfig) {
+		cfg.overrun = overrun
+	}
+}
+
+// Periodic runs a function every interval, never running it concurrently.
+// It can be started and stopped multiple times until it is closed.
+type Periodic struct {
+	interval   time.Duration
+	f          func(ctx context.Context) error
+	jitter     time.Duration
+	immediate  bool
+	runTimeout time.Duration
+	overrun    Overrun
+	state      Many
+	trigger    chan struct{}
+	mu         sync.Mutex
+	stop       context.CancelFunc
+	err        error
+}
+
+// NewPeriodic creates Periodic with the given parent context,
+// which runs f every interval once started.
+// The context passed to f is canceled when Periodic is stopped or closed.
+//
+// Panics if interval is not positive.
+func NewPeriodic(
+	parent context.Context,
+	interval time.Duration,
+	f func(ctx context.Context) error,
+	opts ...PeriodicOption,
+) *Periodic {
+	if interval <= 0 {
+		panic("shot: non-positive interval for NewPeriodic")
+	}
+
+	cfg := defaultPeriodicConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Periodic{
+		interval:   interval,
+		f:          f,
+		jitter:     cfg.jitter,
+		immediate:  cfg.immediate,
+		runTimeout: cfg.runTimeout,
+		overrun:    cfg.overrun,
+		state:      NewMany(parent),
+		trigger:    make(chan struct{}, 1),
+		mu:         sync.Mutex{},
+		stop:       nil,
+		err:        nil,
+	}
+}
+
+// Start starts running the function in a separate goroutine.
+//
+// Returns an error if Periodic has been closed or currently running.
+func (p *Periodic) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stop, err := p.state.Start()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(p.state.Context())
+	p.stop = cancel
+
+	go func() {
+		defer stop()
+		defer cancel()
+		p.loop(ctx)
+	}()
+
+	return nil
+}
+
+// Stop stops running the function and waits for the current run to finish.
+// The context passed to the function is canceled.
+// Periodic can be started again afterwards.
+//
+// Context passed to this method can be canceled to pass control back to the caller
+// if the current run takes too much time to finish.
+func (p *Periodic) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	stop := p.stop
+	p.stop = nil
+	// Done must be obtained before the goroutine exits,
+	// since it is replaced when Periodic is started again.
+	done := p.state.Done()
+	p.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+	stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+	}
+
+	return nil
+}
+
+// Close stops running the function and prevents Periodic from being started again.
+// Waits for the current run to finish, like Stop.
+func (p *Periodic) Close(ctx context.Context) error {
+	return p.state.Close(ctx)
+}
+
+// Trigger makes the function run immediately, without affecting the schedule.
+// If the function is running, it is run again right after the current run finishes.
+// Multiple triggers during a run result in a single run.
+//
+// Does nothing if Periodic is not running.
+func (p *Periodic) Trigger() {
+	if p.state.State() != StateRunning {
+		return
+	}
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Done returns the channel that is closed
+// when Periodic is stopped or closed.
+func (p *Periodic) Done() <-chan struct{} {
+	return p.state.Done()
+}
+
+// State returns current state of Periodic.
+func (p *Periodic) State() State {
+	return p.state.State()
+}
+
+// Err returns the error returned from the last run of the function.
+// If the function panics, returns PanicError.
+func (p *Periodic) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *Periodic) loop(ctx context.Context) {
+	// Triggers not handled before stopping are discarded.
+	defer func() {
+		select {
+		case <-p.trigger:
+		default:
+		}
+	}()
+
+	timer := time.NewTimer(p.interval)
+	defer timer.Stop()
+
+	next := time.Now().Add(p.interval)
+	pending := p.immediate
+
+	for {
+		if !pending {
+			timer.Reset(time.Until(next) + p.randJitter())
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.trigger:
+			case <-timer.C:
+				next = next.Add(p.interval)
+			}
+		}
+		pending = false
+
+		p.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if now := time.Now(); !now.Before(next) {
+			missed := now.Sub(next)/p.interval + 1
+			next = next.Add(missed * p.interval)
+			pending = p.overrun == OverrunQueue
+		}
+	}
+}
+
+func (p *Periodic) run(ctx context.Context) {
+	if p.runTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.runTimeout)
+		defer cancel()
+	}
+
+	err := protect(func() error {
+		return p.f(ctx)
+	})
+
+	p.mu.Lock()
+	p.err = err
+	p.mu.Unlock()
+}
+
+func (p *Periodic) randJitter() time.Duration {
+	if p.jitter <= 0 {
+		return 0
+	}
+	return rand.N(p.jitter)
+}
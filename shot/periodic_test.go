@@ -0,0 +1,168 @@
+package shot_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/infastin/gorack/shot"
+)
+
+func TestPeriodic(t *testing.T) {
+	var runs atomic.Int32
+	p := shot.NewPeriodic(context.Background(), 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start(): unexpected error: %v", err)
+	}
+	if err := p.Start(); !errors.Is(err, shot.ErrRunning) {
+		t.Errorf("Start(): expected=%v got=%v", shot.ErrRunning, err)
+	}
+
+	time.Sleep(55 * time.Millisecond)
+
+	if err := p.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop(): unexpected error: %v", err)
+	}
+	shouldBe(t, p, shot.StateStopped)
+
+	if n := runs.Load(); n < 3 || n > 6 {
+		t.Errorf("expected about 5 runs, got %d", n)
+	}
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start(): unexpected error: %v", err)
+	}
+	shouldBe(t, p, shot.StateRunning)
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+	shouldBe(t, p, shot.StateClosed)
+
+	if err := p.Start(); !errors.Is(err, shot.ErrClosed) {
+		t.Errorf("Start(): expected=%v got=%v", shot.ErrClosed, err)
+	}
+}
+
+func TestPeriodic_Trigger(t *testing.T) {
+	runs := make(chan struct{}, 1)
+	p := shot.NewPeriodic(context.Background(), time.Hour, func(ctx context.Context) error {
+		runs <- struct{}{}
+		return nil
+	})
+	defer p.Close(context.Background())
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start(): unexpected error: %v", err)
+	}
+
+	for range 3 {
+		p.Trigger()
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatal("expected the function to run")
+		}
+	}
+}
+
+func TestPeriodic_immediate(t *testing.T) {
+	ran := make(chan struct{})
+	errFailed := errors.New("failed")
+	p := shot.NewPeriodic(context.Background(), time.Hour, func(ctx context.Context) error {
+		close(ran)
+		return errFailed
+	}, shot.WithImmediate())
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start(): unexpected error: %v", err)
+	}
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the function to run immediately")
+	}
+
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+	if err := p.Err(); !errors.Is(err, errFailed) {
+		t.Errorf("Err(): expected=%v got=%v", errFailed, err)
+	}
+}
+
+func TestPeriodic_runTimeout(t *testing.T) {
+	p := shot.NewPeriodic(context.Background(), time.Hour, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, shot.WithImmediate(), shot.WithRunTimeout(10*time.Millisecond))
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start(): unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for p.Err() == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := p.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Err(): expected=%v got=%v", context.DeadlineExceeded, err)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+}
+
+func TestPeriodic_overrun(t *testing.T) {
+	for _, tt := range []struct {
+		overrun shot.Overrun
+		min     int32
+		max     int32
+	}{
+		{overrun: shot.OverrunSkip, min: 2, max: 2},
+		{overrun: shot.OverrunQueue, min: 3, max: 3},
+	} {
+		t.Run(tt.overrun.String(), func(t *testing.T) {
+			var runs atomic.Int32
+			p := shot.NewPeriodic(context.Background(), 40*time.Millisecond, func(ctx context.Context) error {
+				// The first run misses two ticks.
+				if runs.Add(1) == 1 {
+					time.Sleep(100 * time.Millisecond)
+				}
+				return nil
+			}, shot.WithImmediate(), shot.WithOverrun(tt.overrun))
+
+			if err := p.Start(); err != nil {
+				t.Fatalf("Start(): unexpected error: %v", err)
+			}
+			time.Sleep(150 * time.Millisecond)
+			if err := p.Close(context.Background()); err != nil {
+				t.Fatalf("Close(): unexpected error: %v", err)
+			}
+
+			if n := runs.Load(); n < tt.min || n > tt.max {
+				t.Errorf("expected %d..%d runs, got %d", tt.min, tt.max, n)
+			}
+		})
+	}
+}
+
+func TestNewPeriodic_nonPositiveInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewPeriodic(): expected to panic")
+		}
+	}()
+
+	shot.NewPeriodic(context.Background(), 0, func(ctx context.Context) error {
+		return nil
+	})
+}
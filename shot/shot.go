@@ -2,6 +2,7 @@ package shot
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 )
 
@@ -12,11 +13,12 @@ type E struct {
 
 // GoErr starts a goroutine and returns E,
 // which stores the error returned from the goroutine.
+// If the goroutine panics, PanicError is stored instead.
 // Does not provide any way to determite when goroutine exited.
 func GoErr(f func() error) *E {
 	e := &E{err: atomic.Value{}}
 	go func() {
-		if err := f(); err != nil {
+		if err := protect(f); err != nil {
 			e.err.Store(err)
 		}
 	}()
@@ -47,13 +49,23 @@ type G struct {
 // Returns G, which can be used to control the goroutine
 // and get the error returned from the goroutine.
 //
+// If the goroutine panics, PanicError is stored as its error.
+//
 // NOTE: Goroutine must make use of (*One).Start for G to function properly.
+// If the goroutine panics while One is running, One is closed on its behalf.
 func Go(ctx context.Context, f func(state *One) error) *G {
 	g := &G{s: NewOne(ctx), err: atomic.Value{}}
 	go func() {
-		if err := f(&g.s); err != nil {
+		err := protect(func() error {
+			return f(&g.s)
+		})
+		if err != nil {
 			g.err.Store(err)
 		}
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) && g.s.State() == StateRunning {
+			g.s.onExit()
+		}
 	}()
 	return g
 }
@@ -64,8 +76,10 @@ func Go(ctx context.Context, f func(state *One) error) *G {
 // Returns G, which can be used to control the goroutine
 // and get the error returned from the goroutine.
 //
+// If the goroutine panics, PanicError is stored as its error.
+//
 // NOTE: Compared to Go function, this one calls (*One).Start before f is called
-// and calls stop function returned from (*One).Start when f returns.
+// and calls stop function returned from (*One).Start when f returns or panics.
 func GoCtx(ctx context.Context, f func(ctx context.Context) error) *G {
 	g := &G{s: NewOne(ctx), err: atomic.Value{}}
 	go func() {
@@ -74,7 +88,10 @@ func GoCtx(ctx context.Context, f func(ctx context.Context) error) *G {
 			g.err.Store(err)
 			return
 		}
-		if err := f(g.s.Context()); err != nil {
+		err = protect(func() error {
+			return f(g.s.Context())
+		})
+		if err != nil {
 			g.err.Store(err)
 		}
 		stop()
@@ -92,6 +109,11 @@ func (g *G) Done() <-chan struct{} {
 	return g.s.Done()
 }
 
+// State returns current state of One passed to the goroutine.
+func (g *G) State() State {
+	return g.s.State()
+}
+
 // Err returns the error returned from the goroutine.
 // If goroutine hasn't yet exited, returns nil.
 // If goroutine exited without error, also returns nil.